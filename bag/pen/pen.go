@@ -58,37 +58,46 @@ func (n *Nib) Mask() *image.Alpha {
 
 // pen.Line(bg, image.Pt(10, 10), image.Pt(100, 90), 10, blue)
 func Line(dst draw.Image, sp image.Point, ep image.Point, w int, c color.Color) {
-	w2 := w / 2
-	if w2 == 0 {
-		w2 = 1
-	}
 	nib := (&Nib{w, Circle}).Mask()
 
 	draw := func() func(x, y int) {
 		if nib == nil {
 			return func(x, y int) {
 				rect := image.Rect(x, y, x+w, y+w)
-				rect.Add(image.Pt(-w/2, -w/2))
+				rect = rect.Add(image.Pt(-w/2, -w/2))
 				draw.Draw(dst, rect, &image.Uniform{c}, image.ZP, draw.Over)
 			}
 		}
 		return func(x, y int) {
 			rect := image.Rect(x, y, x+w, y+w)
-			rect.Add(image.Pt(-w/2, -w/2))
+			rect = rect.Add(image.Pt(-w/2, -w/2))
 			draw.DrawMask(dst, rect, &image.Uniform{c}, image.ZP, nib, image.ZP, draw.Over)
 		}
 	}()
 
 	slope := ep.Sub(sp)
 
-	m := float64(slope.Y) / float64(slope.X)
-	b := sp.Y - int(m*float64(sp.X))
-
-	y := sp.Y
-
-	for x := sp.X; x <= ep.X; x++ {
-		y = int(m*float64(x)) + b
-		draw(x, y)
+	// Walk along the longest side so steep lines are not broken.
+	if absMax(slope.X, 0) >= absMax(0, slope.Y) {
+		if sp.X > ep.X {
+			sp, ep = ep, sp
+		}
+		if sp.X == ep.X {
+			draw(sp.X, sp.Y)
+			return
+		}
+		m := float64(ep.Y-sp.Y) / float64(ep.X-sp.X)
+		for x := sp.X; x <= ep.X; x++ {
+			draw(x, sp.Y+int(m*float64(x-sp.X)))
+		}
+		return
 	}
 
+	if sp.Y > ep.Y {
+		sp, ep = ep, sp
+	}
+	m := float64(ep.X-sp.X) / float64(ep.Y-sp.Y)
+	for y := sp.Y; y <= ep.Y; y++ {
+		draw(sp.X+int(m*float64(y-sp.Y)), y)
+	}
 }
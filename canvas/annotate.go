@@ -0,0 +1,360 @@
+package canvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/cgxeiji/plt/bag/pen"
+)
+
+// Coords defines the coordinate system of a point of an Annotation.
+type Coords byte
+
+const (
+	// DataCoords locates a point in the data coordinates of the Axes.
+	DataCoords Coords = iota
+	// AxesCoords locates a point as a fraction of the Axes,
+	// (0, 0) being its lower left corner and (1, 1) its upper right corner.
+	AxesCoords
+	// FigurePixels locates a point in pixels from the lower left corner
+	// of the Figure.
+	FigurePixels
+	// OffsetPoints locates the text of an Annotation in points
+	// relative to the annotated point.
+	OffsetPoints
+)
+
+// ArrowStyle defines how the arrow of an Annotation is drawn.
+type ArrowStyle byte
+
+const (
+	// ArrowNone draws no arrow.
+	ArrowNone ArrowStyle = iota
+	// ArrowSimple draws a straight line with a triangular head.
+	ArrowSimple
+	// ArrowFancy draws a tapered arrow that widens towards its head.
+	ArrowFancy
+	// ArrowCurved draws a curved line with a triangular head.
+	ArrowCurved
+)
+
+// BoxStyle defines the box drawn behind the text of an Annotation.
+type BoxStyle struct {
+	// Pad is the space in points between the text and the border.
+	Pad int
+	// Radius is the radius in points of the rounded corners.
+	Radius int
+	// Fill and Edge are the colors of the background and the border.
+	// A nil Edge draws no border.
+	Fill, Edge color.Color
+}
+
+// annotateConfig holds the optional features requested for an Annotation.
+type annotateConfig struct {
+	xyCoords, textCoords Coords
+	textSet              bool
+	arrow                ArrowStyle
+	curve                float64
+	box                  *BoxStyle
+}
+
+// XYCoords sets the coordinate system of the annotated point.
+// It is also used for the text unless TextCoords is set.
+func XYCoords(c Coords) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.annotate.xyCoords = c
+	}
+}
+
+// TextCoords sets the coordinate system of the text of an Annotation.
+func TextCoords(c Coords) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.annotate.textCoords = c
+		cfg.annotate.textSet = true
+	}
+}
+
+// Arrow draws an arrow from the text of an Annotation to the annotated
+// point.
+func Arrow(style ArrowStyle) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.annotate.arrow = style
+	}
+}
+
+// Curve sets the bend of an ArrowCurved relative to its length.
+// Negative values bend the arrow to the other side.
+func Curve(bend float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.annotate.curve = bend
+	}
+}
+
+// TextBox draws a box behind the text of an Annotation.
+func TextBox(style BoxStyle) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.annotate.box = &style
+	}
+}
+
+// Annotation represents a text pointing to a location of its parent Axes.
+//
+// Annotations are not clipped to the data area of the Axes by default.
+type Annotation struct {
+	primitive
+	Parent *Axes
+	Text   string
+	// XY is the annotated point and XYText the center of the text.
+	XY, XYText           [2]float64
+	XYCoords, TextCoords Coords
+	Arrow                ArrowStyle
+	// Curve is the bend of an ArrowCurved relative to its length.
+	Curve float64
+	// Box is drawn behind the text if it is not nil.
+	Box *BoxStyle
+	// H is the height of the text relative to the shortest side of the Axes.
+	H float64
+}
+
+// Annotate attaches text at xytext pointing to the point xy.
+// Both points are in data coordinates unless the options XYCoords and
+// TextCoords say otherwise. An arrow and a box behind the text can be
+// added with the options Arrow and TextBox. The option Color sets the
+// color of the text and the arrow.
+func (ax *Axes) Annotate(text string, xy, xytext [2]float64, opts ...PlotOption) (*Annotation, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	cfg := newPlotConfig(append([]PlotOption{Curve(0.2)}, opts...))
+	ann := cfg.annotate
+
+	var a Annotation
+	a.Parent = ax
+	a.Text = text
+	a.XY = xy
+	a.XYText = xytext
+	a.XYCoords = ann.xyCoords
+	a.TextCoords = ann.xyCoords
+	if ann.textSet {
+		a.TextCoords = ann.textCoords
+	}
+	a.Arrow = ann.arrow
+	a.Curve = ann.curve
+	a.Box = ann.box
+	a.H = 0.04
+	a.XAlign = CenterAlign
+	a.YAlign = CenterAlign
+	a.NoClip = true
+	a.t = ax.dataT
+	a.FillColor = cfg.colorOr(ax.Parent.theme.TextColor)
+
+	ax.children = append(ax.children, &a)
+	return &a, nil
+}
+
+// point returns the pixel of p in the coordinate system c.
+// Offsets in points are relative to the pixel ref.
+func (a *Annotation) point(p [2]float64, c Coords, ref [2]float64) (x, y float64) {
+	switch c {
+	case AxesCoords:
+		b := a.Parent.Bounds()
+		return float64(b.Min.X) + p[0]*float64(b.Dx()), float64(b.Max.Y) - p[1]*float64(b.Dy())
+	case FigurePixels:
+		return p[0], a.Parent.Parent.Size[1] - p[1]
+	case OffsetPoints:
+		f := a.Parent.Parent
+		return ref[0] + f.px(p[0]), ref[1] - f.px(p[1])
+	}
+	return pixel(a, p[0], p[1])
+}
+
+// layout returns the font of the Annotation, the pixels covered by its
+// text and box, and the annotated point in pixels.
+func (a *Annotation) layout() (*fontType, image.Rectangle, [2]float64) {
+	var target [2]float64
+	target[0], target[1] = a.point(a.XY, a.XYCoords, [2]float64{})
+	tx, ty := a.point(a.XYText, a.TextCoords, target)
+
+	b := a.Parent.Bounds()
+	height := int(float64(min(b.Dx(), b.Dy())) * a.H)
+	typer := a.Parent.Parent.newFont(height)
+	typer.Drawer.Src = &image.Uniform{a.Color()}
+	typer.XAlign, typer.YAlign = a.XAlign, a.YAlign
+
+	w, h, ox, oy := typer.box(a.Text)
+	x, y := int(tx-ox), int(ty-oy)
+	r := image.Rect(x, y, x+w, y+h)
+	if a.Box != nil {
+		r = r.Inset(-a.Parent.Parent.pxi(a.Box.Pad))
+	}
+	return typer, r, target
+}
+
+// Extent returns the pixels covered by the text and box of the Annotation.
+func (a *Annotation) Extent() image.Rectangle {
+	_, r, _ := a.layout()
+	return r
+}
+
+// footprint returns the pixels covered by the Annotation with its arrow.
+func (a *Annotation) footprint() image.Rectangle {
+	_, box, target := a.layout()
+	f := a.Parent.Parent
+	// The strokes of the box and the arrow are centered on their path.
+	stroke := f.pxi(2)/2 + 1
+	if a.Arrow == ArrowNone {
+		return box.Inset(-stroke)
+	}
+
+	// The head reaches up to twice its length from the target, and
+	// a curved arrow stays inside the triangle of its control points.
+	head := 2 * f.px(12)
+	x, y := int(target[0]), int(target[1])
+	r := box.Union(image.Rect(x, y, x+1, y+1).Inset(-int(math.Ceil(head))))
+	if a.Arrow == ArrowCurved {
+		cx, cy := float64(box.Min.X+box.Max.X)/2, float64(box.Min.Y+box.Max.Y)/2
+		sx, sy := boxExit(box, cx, cy, target[0], target[1])
+		dx, dy := target[0]-sx, target[1]-sy
+		mx, my := (sx+target[0])/2-dy*a.Curve, (sy+target[1])/2+dx*a.Curve
+		r = r.Union(image.Rect(int(mx), int(my), int(mx)+1, int(my)+1))
+	}
+	return r.Inset(-stroke)
+}
+
+// Render draws the arrow, the box and the text of the Annotation.
+func (a *Annotation) Render(dst draw.Image) {
+	typer, r, target := a.layout()
+	f := a.Parent.Parent
+
+	if a.Arrow != ArrowNone {
+		a.renderArrow(dst, r, target)
+	}
+	if a.Box != nil {
+		X, Y := roundedRect(r, f.px(float64(a.Box.Radius)))
+		if a.Box.Fill != nil {
+			fillPixels(dst, X, Y, a.Box.Fill)
+		}
+		if a.Box.Edge != nil {
+			for i := range X {
+				j := (i + 1) % len(X)
+				pen.Line(dst,
+					image.Pt(int(X[i]), int(Y[i])), image.Pt(int(X[j]), int(Y[j])),
+					f.pxi(1), a.Box.Edge)
+			}
+		}
+	}
+
+	inner := r
+	if a.Box != nil {
+		inner = r.Inset(f.pxi(a.Box.Pad))
+	}
+	typer.XAlign = LeftAlign
+	typer.Render(dst, inner.Min.X, inner.Min.Y, a.Text)
+}
+
+// renderArrow draws the arrow from the border of the text box r to
+// the target pixel.
+func (a *Annotation) renderArrow(dst draw.Image, r image.Rectangle, target [2]float64) {
+	c := a.Color()
+	cx, cy := float64(r.Min.X+r.Max.X)/2, float64(r.Min.Y+r.Max.Y)/2
+	sx, sy := boxExit(r, cx, cy, target[0], target[1])
+	dx, dy := target[0]-sx, target[1]-sy
+	length := math.Hypot(dx, dy)
+	if length < 1 {
+		return
+	}
+
+	f := a.Parent.Parent
+	head, w, s := f.px(12), f.pxi(2), f.px(1)
+	switch a.Arrow {
+	case ArrowSimple:
+		ux, uy := dx/length, dy/length
+		pen.Line(dst,
+			image.Pt(int(sx), int(sy)),
+			image.Pt(int(target[0]-ux*head/2), int(target[1]-uy*head/2)),
+			w, c)
+		arrowHead(dst, target[0], target[1], ux, uy, head, c)
+	case ArrowFancy:
+		ux, uy := dx/length, dy/length
+		nx, ny := -uy, ux
+		bx, by := target[0]-ux*head*1.5, target[1]-uy*head*1.5
+		fillPixels(dst,
+			[]float64{
+				sx + nx*s, bx + nx*3*s, bx + nx*head*0.6, target[0],
+				bx - nx*head*0.6, bx - nx*3*s, sx - nx*s,
+			},
+			[]float64{
+				sy + ny*s, by + ny*3*s, by + ny*head*0.6, target[1],
+				by - ny*head*0.6, by - ny*3*s, sy - ny*s,
+			}, c)
+	case ArrowCurved:
+		// Quadratic Bézier curve bent away from the straight line.
+		mx, my := (sx+target[0])/2-dy*a.Curve, (sy+target[1])/2+dx*a.Curve
+		n := int(length/4) + 2
+		px, py := sx, sy
+		for i := 1; i <= n; i++ {
+			t := float64(i) / float64(n)
+			x := (1-t)*(1-t)*sx + 2*(1-t)*t*mx + t*t*target[0]
+			y := (1-t)*(1-t)*sy + 2*(1-t)*t*my + t*t*target[1]
+			pen.Line(dst, image.Pt(int(px), int(py)), image.Pt(int(x), int(y)), w, c)
+			px, py = x, y
+		}
+		tx, ty := target[0]-mx, target[1]-my
+		d := math.Hypot(tx, ty)
+		if d > 0 {
+			arrowHead(dst, target[0], target[1], tx/d, ty/d, head, c)
+		}
+	}
+}
+
+// arrowHead draws a triangle of length l with its tip at (x, y)
+// pointing in the direction (ux, uy).
+func arrowHead(dst draw.Image, x, y, ux, uy, l float64, c color.Color) {
+	nx, ny := -uy, ux
+	bx, by := x-ux*l, y-uy*l
+	fillPixels(dst,
+		[]float64{x, bx + nx*l/3, bx - nx*l/3},
+		[]float64{y, by + ny*l/3, by - ny*l/3},
+		c)
+}
+
+// boxExit returns the point where the segment from the center (cx, cy)
+// of r to (x, y) leaves r.
+func boxExit(r image.Rectangle, cx, cy, x, y float64) (float64, float64) {
+	dx, dy := x-cx, y-cy
+	t := 1.0
+	if dx != 0 {
+		t = math.Min(t, math.Abs(float64(r.Dx())/2/dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, math.Abs(float64(r.Dy())/2/dy))
+	}
+	return cx + dx*t, cy + dy*t
+}
+
+// roundedRect returns the vertices in pixels of r with its corners
+// rounded by a radius.
+func roundedRect(r image.Rectangle, radius float64) (X, Y []float64) {
+	radius = math.Min(radius, math.Min(float64(r.Dx()), float64(r.Dy()))/2)
+	x0, y0 := float64(r.Min.X), float64(r.Min.Y)
+	x1, y1 := float64(r.Max.X), float64(r.Max.Y)
+	if radius <= 0 {
+		return []float64{x0, x1, x1, x0}, []float64{y0, y0, y1, y1}
+	}
+
+	corners := [4][3]float64{
+		{x1 - radius, y0 + radius, -math.Pi / 2},
+		{x1 - radius, y1 - radius, 0},
+		{x0 + radius, y1 - radius, math.Pi / 2},
+		{x0 + radius, y0 + radius, math.Pi},
+	}
+	for _, c := range corners {
+		for i := 0; i <= 6; i++ {
+			t := c[2] + float64(i)*math.Pi/12
+			X = append(X, c[0]+radius*math.Cos(t))
+			Y = append(Y, c[1]+radius*math.Sin(t))
+		}
+	}
+	return X, Y
+}
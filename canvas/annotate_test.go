@@ -0,0 +1,74 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnnotationCoords(t *testing.T) {
+	fig, err := NewFigureInches(4, 3, 144)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	ax.SetXLim(0, 10)
+	ax.SetYLim(0, 5)
+	b := ax.Bounds()
+	// frac returns the pixel at the fraction (x, y) of the Axes.
+	frac := func(x, y float64) [2]float64 {
+		return [2]float64{float64(b.Min.X) + x*float64(b.Dx()), float64(b.Max.Y) - y*float64(b.Dy())}
+	}
+	center := frac(0.5, 0.5)
+
+	tests := []struct {
+		name         string
+		xy, xytext   [2]float64
+		opts         []PlotOption
+		target, text [2]float64
+	}{
+		{
+			name: "data",
+			xy:   [2]float64{5, 2.5}, xytext: [2]float64{2, 1},
+			target: center, text: frac(0.2, 0.2),
+		},
+		{
+			name: "axes",
+			xy:   [2]float64{1, 0}, xytext: [2]float64{0.5, 0.5},
+			opts:   []PlotOption{XYCoords(AxesCoords)},
+			target: frac(1, 0), text: center,
+		},
+		{
+			name: "figure pixels",
+			xy:   [2]float64{100, 50}, xytext: [2]float64{5, 2.5},
+			opts:   []PlotOption{XYCoords(FigurePixels), TextCoords(DataCoords)},
+			target: [2]float64{100, fig.Size[1] - 50}, text: center,
+		},
+		{
+			name: "offset points",
+			xy:   [2]float64{5, 2.5}, xytext: [2]float64{36, 18},
+			opts:   []PlotOption{TextCoords(OffsetPoints)},
+			target: center, text: [2]float64{center[0] + 72, center[1] - 36},
+		},
+		{
+			name: "offset from axes",
+			xy:   [2]float64{0, 1}, xytext: [2]float64{-18, 0},
+			opts:   []PlotOption{XYCoords(AxesCoords), TextCoords(OffsetPoints)},
+			target: frac(0, 1), text: [2]float64{float64(b.Min.X) - 36, float64(b.Min.Y)},
+		},
+	}
+	for _, tt := range tests {
+		a, err := ax.Annotate("note", tt.xy, tt.xytext, tt.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, r, target := a.layout()
+		text := [2]float64{float64(r.Min.X+r.Max.X) / 2, float64(r.Min.Y+r.Max.Y) / 2}
+		for i := range target {
+			if math.Abs(target[i]-tt.target[i]) > 1 || math.Abs(text[i]-tt.text[i]) > 1 {
+				t.Errorf("%v: points at %v with the text at %v, want %v and %v",
+					tt.name, target, text, tt.target, tt.text)
+				break
+			}
+		}
+	}
+}
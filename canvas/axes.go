@@ -5,15 +5,47 @@ import (
 	"image"
 	"image/draw"
 	"log"
-
-	"golang.org/x/image/colornames"
-	"gonum.org/v1/gonum/mat"
+	"math"
 )
 
 // Axes represents a Primitive with Figure as its parent.
+//
+// Plots inside an Axes are drawn in data coordinates.
+// The data limits of the Axes grow to fit every plot attached to it,
+// unless they are fixed with SetXLim or SetYLim.
 type Axes struct {
 	primitive
 	Parent *Figure
+	// XLim and YLim hold the data limits mapped to the borders of the Axes.
+	// They are read-only: the limits are set with SetXLim and SetYLim.
+	XLim, YLim [2]float64
+
+	// tc maps the coordinates of the Axes into the Figure and dataT
+	// maps the data limits into the Axes. The children located in data
+	// coordinates share dataT, so they follow any change of the limits.
+	tc, dataT      *transform
+	xdata, ydata   [2]float64
+	xfixed, yfixed bool
+	axis           [4]*Axis
+	colors         int
+	equal          bool
+	// limits hold the data limits requested for an equal aspect and
+	// shown the limits it widened them to, so the limits are widened
+	// from the requested ones whenever the Axes changes size.
+	limits, shown  [2][2]float64
+	sharex, sharey *shareGroup
+	// host is the Axes overlaid by a twin Axes, which shows its own
+	// dimension on the Axis at twinSide.
+	host     *Axes
+	twinSide Alignment
+	twins    []*Axes
+	entries  []legendEntry
+	legend   *Legend
+	// spec and span locate the Axes in a GridSpec of its Figure,
+	// and sub is the region {x, y, w, h} of the span it covers.
+	spec *GridSpec
+	span [4]int
+	sub  [4]float64
 }
 
 // newAxes creates a new Axes linked to a parent Figure.
@@ -32,20 +64,194 @@ func newAxes(parent *Figure, dims ...float64) (*Axes, error) {
 	ax.Parent = parent
 	ax.Origin = o
 	ax.Size = s
-	Tc := mat.NewDense(3, 3, []float64{
-		s[0], 0, o[0],
-		0, s[1], o[1],
-		0, 0, 1,
-	})
-	ax.T = append(ax.T, parent.T...)
-	ax.T = append(ax.T, Tc)
-	ax.FillColor = colornames.White
+	ax.t = parent.tc
+	ax.tc = newTransform(ax.t, ScaleTranslate(s[0], s[1], o[0], o[1]))
+	ax.FillColor = parent.theme.AxesColor
+
+	ax.dataT = newTransform(ax.tc, Identity())
+	ax.xdata = [2]float64{math.Inf(1), math.Inf(-1)}
+	ax.ydata = [2]float64{math.Inf(1), math.Inf(-1)}
+	ax.XLim = [2]float64{0, 1}
+	ax.YLim = [2]float64{0, 1}
+	ax.sub = [4]float64{0, 0, 1, 1}
 
 	parent.children = append(parent.children, &ax)
 
 	return &ax, nil
 }
 
+// Axis returns the Axis of the Axes at the location loc.
+// The Axis is created the first time it is requested.
+// The parameter loc can be set to BottomAxis, LeftAxis, TopAxis or RightAxis.
+func (ax *Axes) Axis(loc Alignment) *Axis {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.axisAt(loc)
+}
+
+// axisAt returns the Axis at loc, creating it if needed.
+func (ax *Axes) axisAt(loc Alignment) *Axis {
+	if ax.axis[loc] == nil {
+		a, _ := newAxis(ax, loc)
+		ax.axis[loc] = a
+		ax.update()
+	}
+	return ax.axis[loc]
+}
+
+// Grid shows or hides the major grid lines of the bottom and left Axis.
+func (ax *Axes) Grid(show bool) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.axisAt(BottomAxis).Grid.Show = show
+	ax.axisAt(LeftAxis).Grid.Show = show
+}
+
+// SetXLim fixes the X data limits of the Axes and of the Axes
+// sharing them.
+func (ax *Axes) SetXLim(min, max float64) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.setXLim(min, max)
+}
+
+func (ax *Axes) setXLim(min, max float64) {
+	for _, a := range ax.xgroup() {
+		a.XLim = [2]float64{min, max}
+		a.xfixed = true
+		a.update()
+	}
+}
+
+// SetYLim fixes the Y data limits of the Axes and of the Axes
+// sharing them.
+func (ax *Axes) SetYLim(min, max float64) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.setYLim(min, max)
+}
+
+func (ax *Axes) setYLim(min, max float64) {
+	for _, a := range ax.ygroup() {
+		a.YLim = [2]float64{min, max}
+		a.yfixed = true
+		a.update()
+	}
+}
+
+// SetEqualAspect keeps the same scale for X and Y data units,
+// so circles stay round.
+// The data limits are widened to fill the Axes.
+func (ax *Axes) SetEqualAspect(equal bool) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.setEqualAspect(equal)
+}
+
+func (ax *Axes) setEqualAspect(equal bool) {
+	ax.equal = equal
+	ax.limits = [2][2]float64{ax.XLim, ax.YLim}
+	ax.shown = ax.limits
+	ax.update()
+}
+
+// extend grows the data range of the Axes to include X and Y
+// and recalculates the data limits.
+func (ax *Axes) extend(X, Y []float64) {
+	for _, x := range X {
+		ax.xdata[0] = math.Min(ax.xdata[0], x)
+		ax.xdata[1] = math.Max(ax.xdata[1], x)
+	}
+	for _, y := range Y {
+		ax.ydata[0] = math.Min(ax.ydata[0], y)
+		ax.ydata[1] = math.Max(ax.ydata[1], y)
+	}
+
+	ax.autoscale()
+}
+
+// aspect returns the requested data limits of the Axes widened to keep
+// the same number of pixels per data unit in X and Y.
+func (ax *Axes) aspect() (xlim, ylim [2]float64) {
+	xlim, ylim = ax.limits[0], ax.limits[1]
+	b := ax.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return
+	}
+
+	sx := (xlim[1] - xlim[0]) / float64(b.Dx())
+	sy := (ylim[1] - ylim[0]) / float64(b.Dy())
+	switch {
+	case sx > sy:
+		c, d := (ylim[0]+ylim[1])/2, sx*float64(b.Dy())/2
+		ylim = [2]float64{c - d, c + d}
+	case sy > sx:
+		c, d := (xlim[0]+xlim[1])/2, sy*float64(b.Dx())/2
+		xlim = [2]float64{c - d, c + d}
+	}
+	return
+}
+
+// margin returns the range r padded by a fraction m on each side.
+func margin(r [2]float64, m float64) [2]float64 {
+	if r[0] > r[1] {
+		return [2]float64{0, 1}
+	}
+	d := (r[1] - r[0]) * m
+	if d == 0 {
+		d = math.Max(math.Abs(r[0])*m, 0.5)
+	}
+	return [2]float64{r[0] - d, r[1] + d}
+}
+
+// update maps the data limits into the Axes and relocates the ticks
+// of each Axis.
+func (ax *Axes) update() {
+	if ax.equal {
+		// Limits changed since the last update are newly requested.
+		if ax.XLim != ax.shown[0] {
+			ax.limits[0] = ax.XLim
+		}
+		if ax.YLim != ax.shown[1] {
+			ax.limits[1] = ax.YLim
+		}
+		ax.XLim, ax.YLim = ax.aspect()
+		ax.shown = [2][2]float64{ax.XLim, ax.YLim}
+	}
+
+	dx := ax.XLim[1] - ax.XLim[0]
+	dy := ax.YLim[1] - ax.YLim[0]
+	ax.dataT.set(ScaleTranslate(1/dx, 1/dy, -ax.XLim[0]/dx, -ax.YLim[0]/dy))
+
+	for _, a := range ax.axis {
+		if a == nil {
+			continue
+		}
+		switch a.Loc {
+		case BottomAxis, TopAxis:
+			a.Min, a.Max = ax.XLim[0], ax.XLim[1]
+		case LeftAxis, RightAxis:
+			a.Min, a.Max = ax.YLim[0], ax.YLim[1]
+		}
+		a.update()
+	}
+}
+
+// setPosition moves the Axes to the origin o with size s in
+// Figure coordinates.
+// The children follow the Axes because they share its transformation matrix.
+func (ax *Axes) setPosition(o, s [2]float64) {
+	ax.Origin = o
+	ax.Size = s
+	ax.tc.set(ScaleTranslate(s[0], s[1], o[0], o[1]))
+	ax.update()
+	// Twins share the placement matrix, so only their bounds change.
+	for _, t := range ax.twins {
+		t.Origin, t.Size = o, s
+		t.update()
+	}
+}
+
 func minSlice(s []float64) float64 {
 	if len(s) <= 0 {
 		log.Panic("max(s) on an empty slice")
@@ -75,7 +281,10 @@ func maxSlice(s []float64) float64 {
 }
 
 // BarPlot creates a Bar chart inside Axes with X labels and Y values.
-func (ax *Axes) BarPlot(X []string, Y []float64) error {
+// Bars are located at the X data coordinates 0, 1, ..., len(Y)-1.
+func (ax *Axes) BarPlot(X []string, Y []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
 	if X != nil {
 		if len(X) != len(Y) {
 			return fmt.Errorf(
@@ -83,46 +292,40 @@ func (ax *Axes) BarPlot(X []string, Y []float64) error {
 				len(X), len(Y))
 		}
 	}
+	cfg := newPlotConfig(opts)
 
-	maxY := maxSlice(Y) / 0.9
-
-	n := float64(len(Y))
-	var padding = 0.1
-	barW := (2.0 - 4.0*padding) / (3*n - 1)
-	spaceW := barW / 2.0
+	n := len(Y)
+	pos := make([]float64, n)
+	for i := range pos {
+		pos[i] = float64(i)
+	}
+	if err := cfg.checkErrors(pos, Y); err != nil {
+		return err
+	}
+	barW := 2.0 / 3.0
+	c := cfg.colorOr(ax.nextColor())
 
 	for i := range Y {
-		bar, err := newBar(ax,
-			padding+barW/2.0+float64(i)*(barW+spaceW),
-			0,
-			barW,
-			Y[i]/maxY)
+		bar, err := newBar(ax, pos[i], 0, barW, Y[i])
 		if err != nil {
 			return err
 		}
 		bar.XAlign = CenterAlign
+		bar.FillColor = c
 	}
+	ax.addEntry(cfg, c, legendPatch)
 
-	axX, _ := newAxis(ax, BottomAxis)
-
-	if X != nil {
-		axX.Labels(X, padding+spaceW)
+	if err := ax.errorBars(pos, Y, cfg); err != nil {
+		return err
 	}
 
-	maxY = maxSlice(Y)
-	labelsY := []string{}
-	if Y != nil {
-		step := (maxY) / float64(4)
-		for i := 0; i < 5; i++ {
-			labelsY = append(labelsY, fmt.Sprintf("%.2f", step*float64(i)))
-		}
-	}
+	ax.extend([]float64{-0.5, float64(n) - 0.5}, []float64{0})
+	ax.extend(nil, cfg.extentY(Y))
 
-	axY, err := newAxis(ax, LeftAxis)
-	if err != nil {
-		return err
+	if X != nil {
+		ax.side(BottomAxis).setTicks(pos, X)
 	}
-	axY.Labels(labelsY, 0.1)
+	ax.side(LeftAxis)
 
 	return nil
 }
@@ -132,73 +335,92 @@ func vmap(value, fmin, fmax, tmin, tmax float64) float64 {
 }
 
 // ScatterPlot creates a Scatter chart inside Axes with X and Y values.
-func (ax *Axes) ScatterPlot(X, Y []float64) error {
-	if X != nil {
-		if len(X) != len(Y) {
-			return fmt.Errorf(
-				"Dimensions mismatch (X[%v] != Y[%v])",
-				len(X), len(Y))
-		}
+func (ax *Axes) ScatterPlot(X, Y []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if len(X) != len(Y) {
+		return fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
 	}
-
-	maxY := maxSlice(Y) / 0.9
-	maxX := maxSlice(X)
-	labels := []string{}
-	labelsY := []string{}
-
-	var padding = 0.1
+	cfg := newPlotConfig(opts)
+	if err := cfg.checkErrors(X, Y); err != nil {
+		return err
+	}
+	c := cfg.colorOr(ax.nextColor())
 
 	for i := range Y {
-		_, err := NewScatterPoint(ax, vmap(X[i], 0, maxX, padding, 1-padding), Y[i]/maxY)
+		p, err := newScatterPoint(ax, X[i], Y[i])
 		if err != nil {
 			return err
 		}
+		p.FillColor = c
 	}
+	ax.addEntry(cfg, c, legendMarker)
 
-	if X != nil {
-		min := minSlice(X)
-		step := (maxX - min) / float64(len(X))
-		for i := range X {
-			labels = append(labels, fmt.Sprintf("%.2f", min+step*float64(i)))
-		}
+	if err := ax.errorBars(X, Y, cfg); err != nil {
+		return err
 	}
 
-	axX, err := newAxis(ax, BottomAxis)
-	if err != nil {
+	ax.extend(cfg.extentX(X), cfg.extentY(Y))
+
+	ax.side(BottomAxis)
+	ax.side(TopAxis)
+	ax.side(LeftAxis)
+	ax.side(RightAxis)
+
+	return nil
+}
+
+// LinePlot creates a Line chart inside Axes with X and Y values.
+func (ax *Axes) LinePlot(X, Y []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if len(X) != len(Y) {
+		return fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
+	}
+	cfg := newPlotConfig(opts)
+	if err := cfg.checkErrors(X, Y); err != nil {
 		return err
 	}
-	axX.Labels(labels, padding)
 
-	axX2, err := newAxis(ax, TopAxis)
+	l, err := newLine(ax, X, Y)
 	if err != nil {
 		return err
 	}
-	axX2.Labels(labels, padding)
-
-	maxY = maxSlice(Y)
-	if Y != nil {
-		min := minSlice(Y)
-		step := (maxY - min) / float64(4)
-		for i := 0; i < 5; i++ {
-			labelsY = append(labelsY, fmt.Sprintf("%.2f", min+step*float64(i)))
-		}
+	l.FillColor = cfg.colorOr(ax.nextColor())
+	if cfg.downsample.set {
+		l.Downsample = cfg.downsample.method
+		l.Threshold = cfg.downsample.threshold
 	}
+	ax.addEntry(cfg, l.FillColor, legendLine)
 
-	axY, err := newAxis(ax, LeftAxis)
-	if err != nil {
+	if err := ax.errorBars(X, Y, cfg); err != nil {
 		return err
 	}
-	axY.Labels(labelsY, 0.1)
 
-	axY2, err := newAxis(ax, RightAxis)
-	if err != nil {
-		return err
-	}
-	axY2.Labels(labelsY, 0)
+	ax.extend(cfg.extentX(X), cfg.extentY(Y))
+
+	ax.side(BottomAxis)
+	ax.side(LeftAxis)
 
 	return nil
 }
 
+// ClipRect restricts the plots of the Axes to its data area.
+// The Axis of the Axes and the children with NoClip set are not clipped.
+func (ax *Axes) ClipRect(child Container) (image.Rectangle, bool) {
+	if _, ok := child.(*Axis); ok {
+		return image.Rectangle{}, false
+	}
+	if c, ok := child.(interface{ clipped() bool }); ok && !c.clipped() {
+		return image.Rectangle{}, false
+	}
+	return ax.Bounds(), true
+}
+
 func border(dst draw.Image, r image.Rectangle, w int, src image.Image,
 	sp image.Point, op draw.Op) {
 	// inside r
@@ -233,8 +455,18 @@ func border(dst draw.Image, r image.Rectangle, w int, src image.Image,
 		src, sp.Add(image.Pt(-w, 0)), op)
 }
 
-// Render draws the Axes' border on top of drawing its contents.
+// Render draws the Axes' background, grid lines and border before
+// its contents.
 func (ax *Axes) Render(dst draw.Image) {
 	ax.primitive.Render(dst)
-	border(dst, ax.Bounds(), -2, &image.Uniform{colornames.Black}, image.ZP, draw.Src)
+	for _, a := range ax.axis {
+		if a != nil {
+			a.renderGrid(dst)
+		}
+	}
+	t := ax.Parent.theme
+	if t.SpineWidth > 0 {
+		w := ax.Parent.pxi(t.SpineWidth)
+		border(dst, ax.Bounds(), -w, &image.Uniform{t.SpineColor}, image.ZP, draw.Src)
+	}
 }
@@ -0,0 +1,175 @@
+package canvas
+
+import (
+	"image"
+	"image/draw"
+	"testing"
+)
+
+// dataRect returns the pixels between the data coordinates (x0, y0) and
+// (x1, y1) of the Axes.
+func dataRect(ax *Axes, x0, y0, x1, y1 float64) image.Rectangle {
+	t := ax.dataT.pixels()
+	px0, py0 := t.Apply(x0, y0)
+	px1, py1 := t.Apply(x1, y1)
+	return image.Rect(int(px0), int(py0), int(px1), int(py1))
+}
+
+func TestBarPlotData(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	Y := []float64{1, 3, -2}
+	if err := ax.BarPlot([]string{"a", "b", "c"}, Y); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		t.Helper()
+		var bars []*bar
+		for _, c := range ax.children {
+			if b, ok := c.(*bar); ok {
+				bars = append(bars, b)
+			}
+		}
+		if len(bars) != len(Y) {
+			t.Fatalf("%v bars, want %v", len(bars), len(Y))
+		}
+		w := 2.0 / 3.0
+		for i, b := range bars {
+			x := float64(i)
+			want := dataRect(ax, x-w/2, 0, x+w/2, Y[i])
+			if got := b.Bounds(); got != want {
+				t.Errorf("Bar %v covers %v, want %v", i, got, want)
+			}
+		}
+	}
+	check()
+
+	// Bars follow the data limits.
+	ax.SetYLim(-5, 5)
+	ax.SetXLim(-1, 4)
+	check()
+}
+
+func TestScatterPlotData(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	X := []float64{-2, 0, 5, 10}
+	Y := []float64{3, -1, 0, 8}
+	if err := ax.ScatterPlot(X, Y); err != nil {
+		t.Fatal(err)
+	}
+	for _, lim := range [][4]float64{
+		{ax.XLim[0], ax.XLim[1], ax.YLim[0], ax.YLim[1]},
+		{0, 20, -10, 10},
+	} {
+		ax.SetXLim(lim[0], lim[1])
+		ax.SetYLim(lim[2], lim[3])
+
+		var i int
+		for _, c := range ax.children {
+			p, ok := c.(*ScatterPoint)
+			if !ok {
+				continue
+			}
+			b := p.Bounds()
+			cx, cy := ax.dataT.pixels().Apply(X[i], Y[i])
+			center := image.Pt((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2)
+			if d := center.Sub(image.Pt(int(cx), int(cy))); d.X < -1 || d.X > 1 || d.Y < -1 || d.Y > 1 {
+				t.Errorf("Point %v centered at %v, want (%.0f,%.0f)", i, center, cx, cy)
+			}
+			i++
+		}
+		if i != len(X) {
+			t.Errorf("%v points, want %v", i, len(X))
+		}
+	}
+}
+
+func TestPlotErrorBarsMismatch(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	X := []float64{0, 1, 2, 3}
+	Y := []float64{1, 3, 2, 4}
+	short := []float64{0.1, 0.2}
+	label := LegendLabel("wrong")
+
+	tests := []struct {
+		name string
+		plot func() error
+	}{
+		{"BarPlot", func() error { return ax.BarPlot(nil, Y, YErr(short), label) }},
+		{"ScatterPlot", func() error { return ax.ScatterPlot(X, Y, XErr(short), label) }},
+		{"LinePlot", func() error { return ax.LinePlot(X, Y, YErr(Y, short), label) }},
+		{"ErrorBar", func() error { return ax.ErrorBar(X, Y, short, label) }},
+		{"ErrorBar options", func() error { return ax.ErrorBar(X, Y, nil, YErr(X, Y, Y), label) }},
+	}
+	for _, tt := range tests {
+		if err := tt.plot(); err == nil {
+			t.Errorf("%v accepted error bars of the wrong length", tt.name)
+		}
+		if n := len(ax.children); n != 0 {
+			t.Errorf("%v attached %v children", tt.name, n)
+			ax.children = nil
+		}
+		if len(ax.entries) != 0 || ax.colors != 0 {
+			t.Errorf("%v added %v legend entries and took %v colors", tt.name, len(ax.entries), ax.colors)
+			ax.entries, ax.colors = nil, 0
+		}
+	}
+	if ax.XLim != [2]float64{0, 1} || ax.YLim != [2]float64{0, 1} {
+		t.Errorf("Limits changed to %v %v", ax.XLim, ax.YLim)
+	}
+}
+
+// bare is a Container without a primitive.
+type bare struct{}
+
+func (bare) Render(draw.Image)     {}
+func (bare) Children() []Container { return nil }
+
+func TestClipRect(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	line, _ := newLine(ax, []float64{0, 1}, []float64{0, 1})
+	free, _ := newLine(ax, []float64{0, 1}, []float64{1, 0})
+	free.NoClip = true
+	text, _ := newText(ax, 0.5, 0.5, "clipped")
+	note, _ := ax.Annotate("note", [2]float64{0.5, 0.5}, [2]float64{0.2, 0.2})
+
+	tests := []struct {
+		name  string
+		child Container
+		ok    bool
+	}{
+		{"line", line, true},
+		{"line with NoClip", free, false},
+		{"text", text, true},
+		{"annotation", note, false},
+		{"bottom axis", ax.Axis(BottomAxis), false},
+		{"top axis", ax.Axis(TopAxis), false},
+		{"container", bare{}, true},
+	}
+	for _, tt := range tests {
+		r, ok := ax.ClipRect(tt.child)
+		if ok != tt.ok {
+			t.Errorf("%v: clipped %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if ok && r != ax.Bounds() {
+			t.Errorf("%v: clipped to %v, want the Axes %v", tt.name, r, ax.Bounds())
+		}
+	}
+}
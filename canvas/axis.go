@@ -4,9 +4,8 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
-
-	"golang.org/x/image/colornames"
-	"gonum.org/v1/gonum/mat"
+	"math"
+	"strings"
 )
 
 // Constants used to define the location of an Axis primitive.
@@ -17,13 +16,53 @@ const (
 	RightAxis  Alignment = 3
 )
 
+// LabelFit defines how an Axis handles labels that collide with each other.
+type LabelFit byte
+
+// Constants used to define the LabelFit of an Axis.
+const (
+	// FitAuto wraps, rotates or thins the labels, whichever fits first.
+	FitAuto LabelFit = iota
+	// FitNone leaves the labels as they are.
+	FitNone
+	// FitRotate rotates the labels 45 or 90 degrees.
+	FitRotate
+	// FitThin only shows every n-th label.
+	FitThin
+	// FitWrap breaks the labels into lines at their spaces.
+	FitWrap
+)
+
 // Axis represents a Primitive for horizontal and vertical axes with Axes as its parent.
 type Axis struct {
 	primitive
+	// tc maps the coordinates of the ticks and labels into the Axes.
+	tc       *transform
 	Min, Max float64
 	Loc      Alignment
 	Parent   *Axes
 	Typer    *fontType
+	// Grid and MinorGrid define the lines drawn across the Axes at
+	// each major and minor tick.
+	Grid, MinorGrid GridStyle
+	// TickDir is the side of the Axes border where ticks are drawn.
+	TickDir TickDirection
+	// TickLen and MinorTickLen are the length of the ticks in points.
+	TickLen, MinorTickLen int
+	// Minor locates the minor ticks. A nil Minor draws no minor ticks.
+	Minor Locator
+	// Format turns the automatic ticks into labels.
+	// A nil Format uses two fixed decimals.
+	Format Formatter
+	// Rotation is the angle in degrees, counterclockwise, of all the labels.
+	Rotation float64
+	// Fit defines how to handle labels that collide.
+	Fit LabelFit
+
+	values       []float64
+	labels       []string
+	major, minor []float64
+	tickLabels   []*Label
 }
 
 // newAxis creates a new Axis linked to an Axes.
@@ -51,14 +90,13 @@ func newAxis(parent *Axes, location Alignment) (*Axis, error) {
 	ax.Loc = location
 	ax.Origin = o
 	ax.Size = s
-	Tc := mat.NewDense(3, 3, []float64{
-		s[0], 0, o[0],
-		0, s[1], o[1],
-		0, 0, 1,
-	})
-	ax.T = append(ax.T, parent.T...)
-	ax.T = append(ax.T, Tc)
+	ax.t = parent.tc
+	ax.tc = newTransform(ax.t, ScaleTranslate(s[0], s[1], o[0], o[1]))
 	ax.FillColor = color.Transparent
+	ax.Grid = parent.Parent.theme.Grid
+	ax.MinorGrid = parent.Parent.theme.MinorGrid
+	ax.TickLen = 6
+	ax.MinorTickLen = 3
 
 	parent.children = append(parent.children, &ax)
 	return &ax, nil
@@ -68,73 +106,378 @@ func newAxis(parent *Axes, location Alignment) (*Axis, error) {
 // The size of Typer is calculated whenever Axis is requested to render.
 // This ensures the size is updated on any parent's change.
 func (a *Axis) Render(dst draw.Image) {
-	if len(a.children) == 0 {
+	a.prepare()
+}
+
+// prepare sizes the Typer from the height of the labels and resolves
+// their collisions, so the labels can be drawn or measured.
+func (a *Axis) prepare() {
+	var l *Label
+	for _, c := range a.children {
+		if label, ok := c.(*Label); ok {
+			l = label
+			break
+		}
+	}
+	if l == nil {
 		return
 	}
-	l := a.children[0].(*Label)
 	bounds := l.Bounds()
 	height := bounds.Max.Y - bounds.Min.Y
-	t, _ := newFont(height * 72 / 300)
+	t := a.Parent.Parent.newFont(height)
 	t.XAlign = l.XAlign
 	t.YAlign = l.YAlign
 	a.Typer = t
+
+	a.fit()
+}
+
+// fit resolves the collisions between the labels of the Axis according
+// to its Fit.
+// It needs the Typer, so it is called whenever the Axis renders.
+func (a *Axis) fit() {
+	labels := a.tickLabels
+	for _, l := range labels {
+		l.Rotation = a.Rotation
+		l.hidden = false
+		l.lines = nil
+	}
+	if a.Fit == FitNone || a.Rotation != 0 || len(labels) < 2 {
+		return
+	}
+
+	gap := a.Parent.Parent.px(4)
+	horizontal := a.Loc == BottomAxis || a.Loc == TopAxis
+	d := a.Typer.Drawer
+	h := float64(a.Typer.Height.Ceil())
+
+	spacing := math.Inf(1)
+	var maxW float64
+	for i, l := range labels {
+		maxW = math.Max(maxW, float64(d.MeasureString(l.Text).Ceil()))
+		if i == 0 {
+			continue
+		}
+		x0, y0 := pixel(l, labels[i-1].Origin[0], labels[i-1].Origin[1])
+		x1, y1 := pixel(l, l.Origin[0], l.Origin[1])
+		spacing = math.Min(spacing, math.Hypot(x1-x0, y1-y0))
+	}
+
+	need := h + gap
+	if horizontal {
+		need = maxW + gap
+	}
+	if need <= spacing {
+		return
+	}
+
+	if horizontal && (a.Fit == FitAuto || a.Fit == FitWrap) {
+		if a.wrap(spacing - gap) {
+			return
+		}
+	}
+	if horizontal && (a.Fit == FitAuto || a.Fit == FitRotate) {
+		deg := 90.0
+		if h/math.Sin(math.Pi/4)+gap <= spacing {
+			deg = 45
+		}
+		for _, l := range labels {
+			l.Rotation = deg
+		}
+		need = h/math.Sin(deg*math.Pi/180) + gap
+		if need <= spacing {
+			return
+		}
+	}
+	if a.Fit == FitAuto || a.Fit == FitThin {
+		n := int(math.Ceil(need / spacing))
+		for i, l := range labels {
+			l.hidden = i%n != 0
+		}
+	}
+}
+
+// wrap breaks the labels of the Axis into lines no wider than w pixels.
+// It returns false, leaving the labels untouched, if a single word is
+// wider than w.
+func (a *Axis) wrap(w float64) bool {
+	d := a.Typer.Drawer
+	wrapped := make([][]string, len(a.tickLabels))
+	for i, l := range a.tickLabels {
+		var line string
+		for _, word := range strings.Fields(l.Text) {
+			if float64(d.MeasureString(word).Ceil()) > w {
+				return false
+			}
+			if line == "" {
+				line = word
+				continue
+			}
+			if float64(d.MeasureString(line+" "+word).Ceil()) > w {
+				wrapped[i] = append(wrapped[i], line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		wrapped[i] = append(wrapped[i], line)
+	}
+	for i, l := range a.tickLabels {
+		l.lines = wrapped[i]
+	}
+	return true
 }
 
 // Labels adds X labels to the Axis with regular spacing.
+// The labels are fixed to the data coordinates found at their position.
 func (a *Axis) Labels(X []string, padding float64) {
+	a.Parent.Parent.Lock()
+	defer a.Parent.Parent.Unlock()
 	var spacing = (1 - padding*2) / (float64(len(X)) - 1)
+	var start = padding
+	if a.Loc == LeftAxis {
+		spacing = (1 - padding) / (float64(len(X)) - 1)
+		start = 0
+	}
+
+	values := make([]float64, len(X))
+	for i := range X {
+		values[i] = vmap(start+spacing*float64(i), 0, 1, a.Min, a.Max)
+	}
+	a.setTicks(values, X)
+}
 
+// SetTicks fixes the ticks of the Axis at the data coordinates values
+// with the text labels.
+// Setting values to nil restores the automatic ticks.
+func (a *Axis) SetTicks(values []float64, labels []string) {
+	a.Parent.Parent.Lock()
+	defer a.Parent.Parent.Unlock()
+	a.setTicks(values, labels)
+}
+
+func (a *Axis) setTicks(values []float64, labels []string) {
+	a.values = values
+	a.labels = labels
+	a.update()
+}
+
+// SetMinor sets the Locator of the minor ticks of the Axis.
+func (a *Axis) SetMinor(minor Locator) {
+	a.Parent.Parent.Lock()
+	defer a.Parent.Parent.Unlock()
+	a.Minor = minor
+	a.update()
+}
+
+// SetFormatter sets the Formatter of the labels of the Axis.
+func (a *Axis) SetFormatter(format Formatter) {
+	a.Parent.Parent.Lock()
+	defer a.Parent.Parent.Unlock()
+	a.Format = format
+	a.update()
+}
+
+// labelOffset returns the distance in pixels from the Axes border
+// to the labels, leaving space for the ticks drawn outside.
+func (a *Axis) labelOffset() image.Point {
+	f := a.Parent.Parent
+	out, _ := a.TickDir.extent(f.pxi(a.TickLen))
+	d := out + f.pxi(3)
 	switch a.Loc {
 	case BottomAxis:
-		for i := range X {
-			l, _ := newLabel(a, padding+spacing*float64(i), a.Size[0]*(0.4), 0.5, X[i])
-			l.YAlign = TopAlign
-			NewTick(a, padding+spacing*float64(i), a.Size[0]*(0.4), 0.2, 2)
-		}
+		return image.Pt(0, d)
 	case LeftAxis:
-		spacing = (1 - padding) / (float64(len(X)) - 1)
-		for i := range X {
-			l, _ := newLabel(a, a.Size[1]*(0.4), spacing*float64(i), 0.1, X[i])
-			l.XAlign = RightAlign
-			NewTick(a, a.Size[1]*(0.4), spacing*float64(i), 0.2, 2)
-		}
+		return image.Pt(-d, 0)
 	case TopAxis:
-		for i := range X {
-			l, _ := newLabel(a, padding+spacing*float64(i), a.Size[0]*(0.6), 0.5, X[i])
-			l.YAlign = BottomAlign
+		return image.Pt(0, -d)
+	case RightAxis:
+		return image.Pt(d, 0)
+	}
+	return image.ZP
+}
+
+// update relocates the ticks and labels of the Axis between Min and Max.
+func (a *Axis) update() {
+	values, labels := a.values, a.labels
+	var shared string
+	if values == nil {
+		values = niceTicks(a.Min, a.Max, 5)
+		format := a.Format
+		if format == nil {
+			format = FixedFormatter(2)
 		}
+		labels, shared = format.Format(values)
+	}
+
+	lo, hi := math.Min(a.Min, a.Max), math.Max(a.Min, a.Max)
+	var pos, minor []float64
+	var text []string
+	for i, v := range values {
+		if v < lo || v > hi || i >= len(labels) {
+			continue
+		}
+		pos = append(pos, vmap(v, a.Min, a.Max, 0, 1))
+		text = append(text, labels[i])
+	}
+	if a.Minor != nil {
+		for _, v := range a.Minor(lo, hi, values) {
+			if v < lo || v > hi {
+				continue
+			}
+			minor = append(minor, vmap(v, a.Min, a.Max, 0, 1))
+		}
+	}
+	if a.Parent.inner(a.Loc) {
+		text, shared = nil, ""
+	}
+	a.layout(pos, text, minor)
+
+	if shared != "" {
+		a.sharedLabel(shared)
+	}
+}
+
+// sharedLabel adds the text shared by all the labels of the Axis,
+// such as a multiplier, at the end of the Axis.
+func (a *Axis) sharedLabel(text string) {
+	switch a.Loc {
+	case BottomAxis:
+		l, _ := newLabel(a, 1, 0, 0.5, text)
+		l.XAlign = RightAlign
+		l.YAlign = TopAlign
+	case TopAxis:
+		l, _ := newLabel(a, 1, 1, 0.5, text)
+		l.XAlign = RightAlign
+		l.YAlign = BottomAlign
+	case LeftAxis:
+		l, _ := newLabel(a, 0.5, 1.02, 0.1, text)
+		l.XAlign = LeftAlign
+		l.YAlign = BottomAlign
 	case RightAxis:
-		for i := range X {
-			l, _ := newLabel(a, a.Size[1]*(0.6), padding+spacing*float64(i), 0.1, X[i])
+		l, _ := newLabel(a, 0.5, 1.02, 0.1, text)
+		l.XAlign = RightAlign
+		l.YAlign = BottomAlign
+	}
+}
+
+// layout replaces the children of the Axis with labels and major ticks
+// at the positions pos along the Axis and minor ticks at minor.
+// The labels are added first, so Render can size the Typer from them.
+func (a *Axis) layout(pos []float64, X []string, minor []float64) {
+	a.children = nil
+	a.major = pos
+	a.minor = minor
+	a.tickLabels = nil
+
+	for i := range X {
+		var l *Label
+		switch a.Loc {
+		case BottomAxis:
+			l, _ = newLabel(a, pos[i], 0.5, 0.5, X[i])
+			l.YAlign = TopAlign
+		case LeftAxis:
+			l, _ = newLabel(a, 0.5, pos[i], 0.1, X[i])
+			l.XAlign = RightAlign
+		case TopAxis:
+			l, _ = newLabel(a, pos[i], 0.5, 0.5, X[i])
+			l.YAlign = BottomAlign
+		case RightAxis:
+			l, _ = newLabel(a, 0.5, pos[i], 0.1, X[i])
 			l.XAlign = LeftAlign
 		}
+		a.tickLabels = append(a.tickLabels, l)
+	}
+	for _, p := range pos {
+		newTick(a, p, false)
+	}
+	for _, p := range minor {
+		newTick(a, p, true)
 	}
 }
 
+// niceTicks returns the values between min and max that are multiples
+// of a round step, giving about n ticks.
+func niceTicks(min, max float64, n int) []float64 {
+	if min > max {
+		min, max = max, min
+	}
+	span := max - min
+	if span <= 0 || n < 1 || math.IsInf(span, 0) || math.IsNaN(span) {
+		return nil
+	}
+
+	raw := span / float64(n)
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	var step float64
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		step = m * mag
+		if raw <= step {
+			break
+		}
+	}
+
+	var ticks []float64
+	for i := math.Ceil(min / step); i*step <= max+step*1e-9; i++ {
+		v := i * step
+		if v == 0 {
+			v = 0 // avoid -0
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
 // Tick represents a tick to be drawn on an Axis
 type Tick struct {
 	primitive
 	W      int
+	Minor  bool
 	Parent *Axis
 }
 
-// NewTick creates a new Tick linked to an Axis.
+// NewTick creates a new Tick linked to an Axis at (x, y) in the
+// coordinates of the Axis, with a width of w points.
+//
+// Deprecated: Ticks are located with Axis.SetTicks, and their length and
+// direction with the TickLen and TickDir of the Axis. The length l is
+// ignored.
 func NewTick(parent *Axis, x, y, l float64, w int) (*Tick, error) {
+	f := parent.Parent.Parent
+	f.Lock()
+	defer f.Unlock()
+	pos := x
+	if parent.Loc == LeftAxis || parent.Loc == RightAxis {
+		pos = y
+	}
+	t, err := newTick(parent, pos, false)
+	if err != nil {
+		return nil, err
+	}
+	t.W = w
+	return t, nil
+}
+
+// newTick creates a new Tick linked to an Axis at the position pos
+// along the Axis.
+// The length and direction of the Tick are taken from its parent.
+func newTick(parent *Axis, pos float64, minor bool) (*Tick, error) {
 	var t Tick
 
 	t.Parent = parent
-	t.Origin = [2]float64{x, y}
+	t.Minor = minor
 	switch parent.Loc {
-	case BottomAxis:
-		t.Size = [2]float64{0, l}
-	case LeftAxis:
-		t.Size = [2]float64{l, 0}
+	case BottomAxis, TopAxis:
+		t.Origin = [2]float64{pos, 0.5}
+	case LeftAxis, RightAxis:
+		t.Origin = [2]float64{0.5, pos}
 	}
-	t.W = w
-	Tc := mat.DenseCopyOf(iM)
-	t.T = append(t.T, parent.T...)
-	t.T = append(t.T, Tc)
-	t.FillColor = colornames.Black
+	t.W = 2
+	if minor {
+		t.W = 1
+	}
+	t.t = parent.tc
+	t.FillColor = parent.Parent.Parent.theme.TickColor
 
 	parent.children = append(parent.children, &t)
 	return &t, nil
@@ -145,25 +488,35 @@ func (t *Tick) Render(dst draw.Image) {
 	draw.Draw(dst, t.Bounds(), &image.Uniform{t.Color()}, image.ZP, draw.Over)
 }
 
-// Bounds returns a a specific width in pixels.
-func (t *Tick) Bounds() image.Rectangle {
-	var x0, y0, x1, y1 int
+// Extent returns the pixels covered by the Tick.
+func (t *Tick) Extent() image.Rectangle {
+	return t.Bounds()
+}
 
-	v := transform(t)
+// Bounds returns the pixels covered by the Tick, crossing the Axes border
+// according to the TickDir of its parent.
+func (t *Tick) Bounds() image.Rectangle {
+	l := t.Parent.TickLen
+	if t.Minor {
+		l = t.Parent.MinorTickLen
+	}
+	f := t.Parent.Parent.Parent
+	out, in := t.Parent.TickDir.extent(f.pxi(l))
 
-	x0 = int(v.At(0, 0))
-	y0 = int(v.At(1, 0))
-	x1 = int(v.At(0, 1))
-	y1 = int(v.At(1, 1))
+	x, y := pixel(t, t.Origin[0], t.Origin[1])
+	X, Y := int(x), int(y)
+	w := f.pxi(t.W)
+	w0, w1 := w/2, w-w/2
 
-	if x0 == x1 {
-		x0 -= t.W / 2
-		x1 += t.W / 2
-	}
-	if y0 == y1 {
-		y0 -= t.W / 2
-		y1 += t.W / 2
+	switch t.Parent.Loc {
+	case BottomAxis:
+		return image.Rect(X-w0, Y-in, X+w1, Y+out)
+	case TopAxis:
+		return image.Rect(X-w0, Y-out, X+w1, Y+in)
+	case LeftAxis:
+		return image.Rect(X-out, Y-w0, X+in, Y+w1)
+	case RightAxis:
+		return image.Rect(X-in, Y-w0, X+out, Y+w1)
 	}
-
-	return image.Rect(min(x0, x1), min(y0, y1), max(x0, x1), max(y0, y1))
+	return image.Rectangle{}
 }
@@ -0,0 +1,64 @@
+package canvas
+
+import "testing"
+
+func TestAxisFit(t *testing.T) {
+	tests := []struct {
+		name     string
+		loc      Alignment
+		fit      LabelFit
+		rotation float64
+		n        int
+		label    string
+		// The labels are expected rotated, broken into lines and hidden.
+		rotated float64
+		lines   int
+		hidden  int
+	}{
+		{"fits", BottomAxis, FitAuto, 0, 3, "a", 0, 0, 0},
+		{"auto wraps", BottomAxis, FitAuto, 0, 5, "long label text", 0, 3, 0},
+		{"auto rotates 45", BottomAxis, FitAuto, 0, 8, "September", 45, 0, 0},
+		{"auto rotates 90 and thins", BottomAxis, FitAuto, 0, 14, "September", 90, 0, 7},
+		{"auto thins vertically", LeftAxis, FitAuto, 0, 40, "1.00", 0, 0, 32},
+		{"rotate only", BottomAxis, FitRotate, 0, 5, "long label text", 45, 0, 0},
+		{"thin only", BottomAxis, FitThin, 0, 8, "September", 0, 0, 5},
+		{"wrap only", BottomAxis, FitWrap, 0, 8, "September", 0, 0, 0},
+		{"none", BottomAxis, FitNone, 0, 14, "September", 0, 0, 0},
+		{"fixed rotation", BottomAxis, FitAuto, 30, 14, "September", 30, 0, 0},
+	}
+	for _, tt := range tests {
+		fig, err := NewFigure(600, 400)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := fig.NewAxes().Axis(tt.loc)
+		a.Fit = tt.fit
+		a.Rotation = tt.rotation
+		values := make([]float64, tt.n)
+		labels := make([]string, tt.n)
+		for i := range values {
+			values[i] = float64(i) / float64(tt.n-1)
+			labels[i] = tt.label
+		}
+		a.SetTicks(values, labels)
+		a.prepare()
+
+		var hidden int
+		for i, l := range a.tickLabels {
+			if l.hidden {
+				hidden++
+				if i == 0 {
+					t.Errorf("%v: first label hidden", tt.name)
+				}
+			}
+			if l.Rotation != tt.rotated || len(l.lines) != tt.lines {
+				t.Errorf("%v: label %v rotated %v in %v lines, want %v in %v lines",
+					tt.name, i, l.Rotation, len(l.lines), tt.rotated, tt.lines)
+				break
+			}
+		}
+		if hidden != tt.hidden {
+			t.Errorf("%v: %v labels hidden, want %v", tt.name, hidden, tt.hidden)
+		}
+	}
+}
@@ -2,9 +2,6 @@ package canvas
 
 import (
 	"fmt"
-
-	"golang.org/x/image/colornames"
-	"gonum.org/v1/gonum/mat"
 )
 
 // bar is a struct that contains the information necessary to render a single bar in a chart.
@@ -21,6 +18,7 @@ func (b *bar) String() string {
 
 // newBar creates a new *Bar struct belonging to a parent Axes.
 // newBar takes a parent *Axes and a dims [4]float64{startX, startY, width, value(heigth)}
+// in data coordinates.
 func newBar(parent *Axes, dims ...float64) (*bar, error) {
 	var min, max [2]float64
 
@@ -51,14 +49,8 @@ func newBar(parent *Axes, dims ...float64) (*bar, error) {
 	b.Parent = parent
 	b.Origin = min
 	b.Size = max
-	Tc := mat.NewDense(3, 3, []float64{
-		1, 0, 0,
-		0, 1, 0,
-		0, 0, 1,
-	})
-	b.T = append(b.T, parent.T...)
-	b.T = append(b.T, Tc)
-	b.FillColor = colornames.Red
+	b.t = parent.dataT
+	b.FillColor = parent.Parent.theme.color(0)
 
 	parent.children = append(parent.children, &b)
 	return &b, nil
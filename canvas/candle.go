@@ -0,0 +1,266 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"time"
+)
+
+// financeConfig holds the optional features requested for a financial chart.
+type financeConfig struct {
+	up, down color.Color
+	skipGaps bool
+	volume   []float64
+}
+
+// UpColor sets the color of the periods that close higher than they open.
+func UpColor(c color.Color) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.finance.up = c
+	}
+}
+
+// DownColor sets the color of the periods that close lower than they open.
+func DownColor(c color.Color) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.finance.down = c
+	}
+}
+
+// SkipGaps places the periods of a financial chart next to each other,
+// hiding the time without data such as weekends and holidays.
+func SkipGaps() PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.finance.skipGaps = true
+	}
+}
+
+// Volume draws the traded volume of each period as a bar chart below
+// the financial chart, sharing its time axis.
+func Volume(v []float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.finance.volume = v
+	}
+}
+
+// Candle represents a single period of a financial chart
+// with Axes as its parent.
+// The prices and the location X are in data coordinates.
+type Candle struct {
+	primitive
+	Parent                 *Axes
+	X, Width               float64
+	Open, High, Low, Close float64
+	// OHLC draws the open and close prices as ticks instead of a body.
+	OHLC bool
+}
+
+func (c *Candle) String() string {
+	return fmt.Sprintf("Candle {X: %v, Open: %v, High: %v, Low: %v, Close: %v}",
+		c.X, c.Open, c.High, c.Low, c.Close)
+}
+
+// newCandle creates a new Candle at x in data coordinates linked to an Axes.
+func newCandle(parent *Axes, x, width, open, high, low, close float64) (*Candle, error) {
+	if high < low {
+		return &Candle{}, fmt.Errorf(
+			"High %v is lower than Low %v at %v", high, low, x)
+	}
+
+	var c Candle
+	c.Parent = parent
+	c.X = x
+	c.Width = width
+	c.Open, c.High, c.Low, c.Close = open, high, low, close
+	c.t = parent.dataT
+	c.FillColor = parent.Parent.theme.EdgeColor
+
+	parent.children = append(parent.children, &c)
+	return &c, nil
+}
+
+// Render draws the wick and body of the Candle into a draw.Image interface.
+func (c *Candle) Render(dst draw.Image) {
+	src := &image.Uniform{c.Color()}
+
+	x0, high := pixel(c, c.X-c.Width/2, c.High)
+	x1, low := pixel(c, c.X+c.Width/2, c.Low)
+	xc, open := pixel(c, c.X, c.Open)
+	_, close := pixel(c, c.X, c.Close)
+	f := c.Parent.Parent
+	X0, X1, X, W := int(x0), int(x1), int(xc), f.pxi(1)
+	if c.OHLC {
+		W = f.pxi(2)
+	}
+	t := max(1, W/2)
+
+	draw.Draw(dst, image.Rect(X-W/2, int(high), X-W/2+W, int(low)+1), src, image.ZP, draw.Over)
+
+	if c.OHLC {
+		draw.Draw(dst, image.Rect(X0, int(open)-t, X, int(open)+t), src, image.ZP, draw.Over)
+		draw.Draw(dst, image.Rect(X, int(close)-t, X1, int(close)+t), src, image.ZP, draw.Over)
+		return
+	}
+
+	body := image.Rect(X0, int(open), X1, int(close)).Canon()
+	if body.Dy() == 0 {
+		body.Max.Y++
+	}
+	draw.Draw(dst, body, src, image.ZP, draw.Over)
+}
+
+// dateLabels returns about n tick locations from X with the dates T
+// formatted according to the time span of T.
+func dateLabels(T []time.Time, X []float64, n int) ([]float64, []string) {
+	if len(T) == 0 {
+		return nil, nil
+	}
+	layout := "15:04"
+	switch span := T[len(T)-1].Sub(T[0]); {
+	case span > 365*24*time.Hour:
+		layout = "2006-01"
+	case span >= 48*time.Hour:
+		layout = "Jan 02"
+	}
+
+	step := len(T) / n
+	if step < 1 {
+		step = 1
+	}
+	var values []float64
+	var labels []string
+	for i := 0; i < len(T); i += step {
+		values = append(values, X[i])
+		labels = append(labels, T[i].Format(layout))
+	}
+	return values, labels
+}
+
+// financial creates a financial chart inside Axes, drawing each period
+// as a Candle.
+func (ax *Axes) financial(T []time.Time, open, high, low, close []float64, ohlc bool, opts []PlotOption) error {
+	n := len(T)
+	if len(open) != n || len(high) != n || len(low) != n || len(close) != n {
+		return fmt.Errorf(
+			"Dimensions mismatch (T[%v] != open[%v] != high[%v] != low[%v] != close[%v])",
+			n, len(open), len(high), len(low), len(close))
+	}
+	cfg := newPlotConfig(opts)
+	fin := cfg.finance
+	if fin.volume != nil && len(fin.volume) != n {
+		return fmt.Errorf(
+			"Dimensions mismatch (T[%v] != volume[%v])",
+			n, len(fin.volume))
+	}
+	if n == 0 {
+		return nil
+	}
+	// Every period is checked before any Candle is attached.
+	for i := range T {
+		if high[i] < low[i] {
+			return fmt.Errorf(
+				"High %v is lower than Low %v at %v", high[i], low[i], T[i])
+		}
+	}
+	if fin.up == nil {
+		fin.up = ax.Parent.theme.UpColor
+	}
+	if fin.down == nil {
+		fin.down = ax.Parent.theme.DownColor
+	}
+
+	X := make([]float64, n)
+	width := 0.6
+	for i := range T {
+		if fin.skipGaps {
+			X[i] = float64(i)
+			continue
+		}
+		X[i] = float64(T[i].Unix())
+	}
+	if !fin.skipGaps {
+		spacing := math.Inf(1)
+		for i := 1; i < n; i++ {
+			spacing = math.Min(spacing, X[i]-X[i-1])
+		}
+		if math.IsInf(spacing, 1) {
+			spacing = float64(24 * time.Hour / time.Second)
+		}
+		width *= spacing
+	}
+
+	colors := make([]color.Color, n)
+	for i := range T {
+		colors[i] = fin.up
+		if close[i] < open[i] {
+			colors[i] = fin.down
+		}
+		c, err := newCandle(ax, X[i], width, open[i], high[i], low[i], close[i])
+		if err != nil {
+			return err
+		}
+		c.OHLC = ohlc
+		c.FillColor = withAlpha(colors[i], cfg.alpha)
+	}
+
+	ax.extend([]float64{X[0] - width, X[n-1] + width}, high)
+	ax.extend(nil, low)
+
+	values, labels := dateLabels(T, X, 6)
+	ax.axisAt(LeftAxis)
+
+	if fin.volume == nil {
+		ax.axisAt(BottomAxis).setTicks(values, labels)
+		return nil
+	}
+
+	o, s := ax.Origin, ax.Size
+	ax.setPosition([2]float64{o[0], o[1] + 0.3*s[1]}, [2]float64{s[0], 0.7 * s[1]})
+	ax.axisAt(BottomAxis).setTicks(values, make([]string, len(values)))
+
+	vol, err := newAxes(ax.Parent, o[0], o[1], s[0], 0.25*s[1])
+	if err != nil {
+		return err
+	}
+	sub := ax.sub
+	ax.sub = [4]float64{sub[0], sub[1] + 0.3*sub[3], sub[2], 0.7 * sub[3]}
+	vol.sub = [4]float64{sub[0], sub[1], sub[2], 0.25 * sub[3]}
+	if ax.spec != nil {
+		vol.spec, vol.span = ax.spec, ax.span
+		ax.spec.items = append(ax.spec.items, &gridItem{span: vol.span, ax: vol})
+	}
+	for i := range T {
+		b, err := newBar(vol, X[i], 0, width, fin.volume[i])
+		if err != nil {
+			return err
+		}
+		b.XAlign = CenterAlign
+		b.FillColor = withAlpha(colors[i], 0.6)
+	}
+	vol.extend(nil, []float64{0})
+	vol.extend(nil, fin.volume)
+	vol.shareX(ax)
+	vol.axisAt(BottomAxis).setTicks(values, labels)
+	vol.axisAt(LeftAxis)
+
+	return nil
+}
+
+// Candlestick creates a Candlestick chart inside Axes with the open,
+// high, low and close prices of each period starting at T.
+func (ax *Axes) Candlestick(T []time.Time, open, high, low, close []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.financial(T, open, high, low, close, false, opts)
+}
+
+// OHLC creates an Open-High-Low-Close chart inside Axes with the prices
+// of each period starting at T.
+func (ax *Axes) OHLC(T []time.Time, open, high, low, close []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.financial(T, open, high, low, close, true, opts)
+}
@@ -0,0 +1,82 @@
+package canvas
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFinancialEmpty(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	if err := ax.Candlestick(nil, nil, nil, nil, nil); err != nil {
+		t.Errorf("Candlestick of no periods: %v", err)
+	}
+	if err := ax.OHLC([]time.Time{}, nil, nil, nil, nil, Volume([]float64{})); err != nil {
+		t.Errorf("OHLC of no periods: %v", err)
+	}
+	if len(ax.children) != 0 || len(fig.children) != 1 {
+		t.Errorf("Empty charts added %v children to the Axes and %v Axes",
+			len(ax.children), len(fig.children)-1)
+	}
+	if err := ax.Candlestick(nil, nil, nil, nil, nil, Volume([]float64{1})); err == nil {
+		t.Error("Candlestick accepted a volume for no periods")
+	}
+}
+
+func TestFinancialVolumeLimits(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	T := make([]time.Time, 5)
+	prices := []float64{10, 12, 11, 13, 12}
+	for i := range T {
+		T[i] = time.Date(2020, 1, 1+i, 0, 0, 0, 0, time.UTC)
+	}
+	volume := []float64{100, 200, 150, 120, 180}
+	if err := ax.Candlestick(T, prices, prices, prices, prices, SkipGaps(), Volume(volume)); err != nil {
+		t.Fatal(err)
+	}
+	vol, ok := fig.children[len(fig.children)-1].(*Axes)
+	if !ok || vol == ax {
+		t.Fatal("No volume Axes")
+	}
+	if vol.XLim != ax.XLim {
+		t.Errorf("Volume X limits %v, want %v", vol.XLim, ax.XLim)
+	}
+
+	ax.SetXLim(1, 3)
+	if vol.XLim != [2]float64{1, 3} {
+		t.Errorf("Volume X limits %v after SetXLim(1, 3)", vol.XLim)
+	}
+	vol.SetXLim(0, 2)
+	if ax.XLim != [2]float64{0, 2} {
+		t.Errorf("Price X limits %v after SetXLim(0, 2) on the volume", ax.XLim)
+	}
+}
+
+func TestFinancialBadPeriod(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	T := make([]time.Time, 4)
+	for i := range T {
+		T[i] = time.Date(2020, 1, 1+i, 0, 0, 0, 0, time.UTC)
+	}
+	open := []float64{10, 11, 12, 13}
+	high := []float64{12, 13, 11, 15}
+	low := []float64{9, 10, 12.5, 12}
+	if err := ax.Candlestick(T, open, high, low, open, Volume([]float64{1, 2, 3, 4})); err == nil {
+		t.Fatal("Candlestick accepted a High lower than its Low")
+	}
+	if len(ax.children) != 0 || len(fig.children) != 1 {
+		t.Errorf("A failed chart attached %v children to the Axes and %v Axes",
+			len(ax.children), len(fig.children)-1)
+	}
+}
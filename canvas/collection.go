@@ -0,0 +1,146 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Sizes sets the size in points of each marker of a PathCollection.
+func Sizes(s []float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.sizes = s
+	}
+}
+
+// Colors sets the color of each marker of a PathCollection.
+func Colors(c []color.Color) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.colors = c
+	}
+}
+
+// PathCollection represents the markers of a scatter chart located in
+// data coordinates with Axes as its parent.
+//
+// Unlike ScatterPoint, the markers are not Containers of their own:
+// the points are stored as flat slices and drawn in a single pass,
+// so a PathCollection holds millions of points without allocating
+// an object per point.
+type PathCollection struct {
+	primitive
+	Parent *Axes
+	X, Y   []float64
+	// Sizes holds the size in points of each marker. A nil Sizes draws
+	// every marker with the size MarkerSize.
+	Sizes      []float64
+	MarkerSize float64
+	// Colors holds the color of each marker. A nil Colors draws every
+	// marker with the fill color of the PathCollection.
+	Colors []color.Color
+}
+
+func (pc *PathCollection) String() string {
+	return fmt.Sprintf("PathCollection {Points: %v}", len(pc.X))
+}
+
+// newPathCollection creates a new PathCollection with the points X and Y
+// linked to an Axes.
+func newPathCollection(parent *Axes, X, Y []float64) (*PathCollection, error) {
+	if len(X) != len(Y) {
+		return &PathCollection{}, fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
+	}
+
+	var pc PathCollection
+	pc.Parent = parent
+	pc.X = X
+	pc.Y = Y
+	pc.MarkerSize = parent.Parent.theme.MarkerSize
+	pc.t = parent.dataT
+	pc.FillColor = parent.Parent.theme.color(0)
+
+	parent.children = append(parent.children, &pc)
+	return &pc, nil
+}
+
+// Render draws every marker of the PathCollection into a draw.Image
+// interface.
+// The markers are drawn in order, so later points cover earlier ones.
+func (pc *PathCollection) Render(dst draw.Image) {
+	t := pc.Transform()
+	f := pc.Parent.Parent
+	clip := dst.Bounds()
+	size := f.px(pc.MarkerSize)
+	src := &image.Uniform{pc.Color()}
+
+	for i := range pc.X {
+		x, y := t.Apply(pc.X[i], pc.Y[i])
+		if math.IsNaN(x) || math.IsNaN(y) {
+			continue
+		}
+		s := size
+		if pc.Sizes != nil {
+			s = f.px(pc.Sizes[i])
+		}
+		r := image.Rect(int(x-s/2), int(y-s/2), int(x+s/2), int(y+s/2))
+		if !r.Overlaps(clip) {
+			continue
+		}
+		if pc.Colors != nil {
+			src.C = pc.Colors[i]
+		}
+		draw.Draw(dst, r, src, image.ZP, draw.Over)
+	}
+}
+
+// Scatter creates a scatter chart inside Axes with X and Y values,
+// drawn as a single PathCollection. It is meant for large amounts of
+// points, where ScatterPlot would create a Container for each point.
+// The options Sizes and Colors set the size and color of each marker.
+func (ax *Axes) Scatter(X, Y []float64, opts ...PlotOption) (*PathCollection, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	cfg := newPlotConfig(opts)
+	if cfg.sizes != nil && len(cfg.sizes) != len(X) {
+		return nil, fmt.Errorf(
+			"Dimensions mismatch (X[%v] != sizes[%v])",
+			len(X), len(cfg.sizes))
+	}
+	if cfg.colors != nil && len(cfg.colors) != len(X) {
+		return nil, fmt.Errorf(
+			"Dimensions mismatch (X[%v] != colors[%v])",
+			len(X), len(cfg.colors))
+	}
+
+	pc, err := newPathCollection(ax, X, Y)
+	if err != nil {
+		return nil, err
+	}
+	c := cfg.colorOr(ax.nextColor())
+	pc.FillColor = c
+	pc.Sizes = cfg.sizes
+	pc.Colors = cfg.colors
+	if pc.Colors != nil && cfg.alpha < 1 {
+		pc.Colors = make([]color.Color, len(cfg.colors))
+		for i, col := range cfg.colors {
+			pc.Colors[i] = withAlpha(col, cfg.alpha)
+		}
+	}
+	if len(pc.Colors) > 0 {
+		c = pc.Colors[0]
+	}
+	ax.addEntry(cfg, c, legendMarker)
+
+	ax.extend(X, Y)
+
+	ax.side(BottomAxis)
+	ax.side(TopAxis)
+	ax.side(LeftAxis)
+	ax.side(RightAxis)
+
+	return pc, nil
+}
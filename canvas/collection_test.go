@@ -0,0 +1,123 @@
+package canvas
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// scatterData returns n points with a size and a color for each one.
+func scatterData(n int) (X, Y, S []float64, C []color.Color) {
+	for i := 0; i < n; i++ {
+		X = append(X, float64(i))
+		Y = append(Y, math.Sin(float64(i)/100))
+		S = append(S, float64(2+i%5))
+		C = append(C, Palette[i%len(Palette)])
+	}
+	return
+}
+
+// scatterFigures returns the Axes of two Figures plotting the same n
+// points, with ScatterPlot and with Scatter.
+func scatterFigures(t *testing.T, n int) (points, collection *Axes) {
+	var axs []*Axes
+	for i := 0; i < 2; i++ {
+		fig, err := NewFigure(800, 600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		axs = append(axs, fig.NewAxes())
+	}
+	X, Y, _, _ := scatterData(n)
+	if err := axs[0].ScatterPlot(X, Y); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := axs[1].Scatter(X, Y); err != nil {
+		t.Fatal(err)
+	}
+	return axs[0], axs[1]
+}
+
+// renderData draws the data children of the Axes clipped to its bounds.
+func renderData(ax *Axes) *image.RGBA {
+	dst := image.NewRGBA(ax.Parent.Bounds())
+	clip := Clip(dst, ax.Bounds())
+	for _, c := range ax.children {
+		c.Render(clip)
+	}
+	return dst
+}
+
+func TestPathCollectionPixels(t *testing.T) {
+	points, collection := scatterFigures(t, 2000)
+	want := renderData(points)
+	got := renderData(collection)
+	if bytes.Count(want.Pix, []byte{0}) == len(want.Pix) {
+		t.Fatal("No ScatterPoint drawn")
+	}
+	if !bytes.Equal(got.Pix, want.Pix) {
+		t.Error("PathCollection does not draw the pixels of the ScatterPoints")
+	}
+
+	// The markers follow the limits alike.
+	points.SetXLim(100, 300)
+	collection.SetXLim(100, 300)
+	want = renderData(points)
+	got = renderData(collection)
+	if !bytes.Equal(got.Pix, want.Pix) {
+		t.Error("PathCollection does not draw the pixels of the ScatterPoints after SetXLim")
+	}
+}
+
+func TestPathCollectionAllocs(t *testing.T) {
+	fig, err := NewFigure(800, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	X, Y, S, C := scatterData(10000)
+	pc, err := fig.NewAxes().Scatter(X, Y, Sizes(S), Colors(C))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := image.NewRGBA(fig.Bounds())
+	// Render allocates its source color once, not once per point.
+	if n := testing.AllocsPerRun(10, func() { pc.Render(dst) }); n > 1 {
+		t.Errorf("Render of %v points allocates %v times", len(X), n)
+	}
+}
+
+func BenchmarkRenderPathCollection(b *testing.B) {
+	fig, _ := NewFigure(800, 600)
+	X, Y, S, C := scatterData(100000)
+	pc, err := fig.NewAxes().Scatter(X, Y, Sizes(S), Colors(C))
+	if err != nil {
+		b.Fatal(err)
+	}
+	dst := image.NewRGBA(fig.Bounds())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pc.Render(dst)
+	}
+}
+
+func BenchmarkRenderScatterPoints(b *testing.B) {
+	fig, _ := NewFigure(800, 600)
+	ax := fig.NewAxes()
+	X, Y, _, _ := scatterData(100000)
+	if err := ax.ScatterPlot(X, Y); err != nil {
+		b.Fatal(err)
+	}
+	dst := image.NewRGBA(fig.Bounds())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range ax.children {
+			if p, ok := c.(*ScatterPoint); ok {
+				p.Render(dst)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package canvas
+
+import (
+	"image/color"
+)
+
+// Palette holds the colors of DefaultTheme assigned in turn to the plots
+// of an Axes that do not define their own color.
+var Palette = []color.Color{
+	color.RGBA{0x1f, 0x77, 0xb4, 0xff},
+	color.RGBA{0xff, 0x7f, 0x0e, 0xff},
+	color.RGBA{0x2c, 0xa0, 0x2c, 0xff},
+	color.RGBA{0xd6, 0x27, 0x28, 0xff},
+	color.RGBA{0x94, 0x67, 0xbd, 0xff},
+	color.RGBA{0x8c, 0x56, 0x4b, 0xff},
+	color.RGBA{0xe3, 0x77, 0xc2, 0xff},
+	color.RGBA{0x7f, 0x7f, 0x7f, 0xff},
+	color.RGBA{0xbc, 0xbd, 0x22, 0xff},
+	color.RGBA{0x17, 0xbe, 0xcf, 0xff},
+}
+
+// nextColor returns the next color of the Palette for the Axes.
+// Twin Axes take their colors from the Palette of their host.
+func (ax *Axes) nextColor() color.Color {
+	if ax.host != nil {
+		return ax.host.nextColor()
+	}
+	c := ax.Parent.theme.color(ax.colors)
+	ax.colors++
+	return c
+}
+
+// withAlpha returns c with its opacity multiplied by alpha [0, 1].
+func withAlpha(c color.Color, alpha float64) color.Color {
+	if alpha >= 1 {
+		return c
+	}
+	if alpha < 0 {
+		alpha = 0
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = uint8(float64(n.A) * alpha)
+	return n
+}
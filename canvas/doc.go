@@ -13,6 +13,65 @@
 //   |- Axes (figure.NewAxes(), figure.SubAxes(c, r))
 //       |- Bar Chart (axes.BarPlot(X, Y))
 //       |- Scatter Point Chart (axes.ScatterPlot(X, Y))
+//       |- Path Collection (axes.Scatter(X, Y))
+//       |- Line Chart (axes.LinePlot(X, Y))
+//       |- Error Bar Chart (axes.ErrorBar(X, Y, Yerr))
+//       |- Filled Area (axes.FillBetween(X, Y1, Y2), axes.StackPlot(X, Ys))
+//       |- Pie Chart (axes.Pie(values, labels))
+//       |- Financial Chart (axes.Candlestick(T, open, high, low, close), axes.OHLC(...))
+//       |- Twin Axes (axes.TwinX(), axes.TwinY())
+//       |- Legend (axes.Legend())
+//       |- Inset Axes (axes.Inset(bounds), axes.IndicateZoom(inset))
+//       |- Annotation (axes.Annotate(text, xy, xytext))
+//       |- Reference Line (axes.HLine(y), axes.VLine(x), axes.SlopeLine(x, y, slope))
+//       |- Span (axes.HSpan(y0, y1), axes.VSpan(x0, x1))
+//   |- PolarAxes (figure.NewPolarAxes())
+//       |- Line Chart (polar.LinePlot(theta, r))
+//       |- Scatter Point Chart (polar.ScatterPlot(theta, r))
+//       |- Rose Diagram (polar.Bar(theta, r, width))
+//   |- GridSpec (figure.NewGridSpec(rows, cols))
+//       |- Axes (gs.Axes(row0, row1, col0, col1))
+//       |- GridSpec (gs.SubGridSpec(row0, row1, col0, col1, rows, cols))
+//
+// Plots are located in the data coordinates of their Axes.
+// Optional features, such as error bars, are set with PlotOption:
+//  axes.ScatterPlot(X, Y, canvas.YErr(std), canvas.XErr(low, high))
+//
+// Lines with more points than DefaultDownsampleThreshold are reduced to
+// a few points per pixel column before they are drawn, keeping their
+// peaks. The method and threshold are set for each Line with:
+//  axes.LinePlot(X, Y, canvas.Downsample(canvas.LTTB, 10000))
+//
+// Axes can share their data limits, hiding the inner tick labels:
+//  axes, err := fig.SubAxes(3, 1, canvas.ShareX())
+//
+// Plots are clipped to the data area of their Axes. Set NoClip on
+// an element to draw it over the border of its Axes.
+//
+// The look of a Figure is defined by its Theme, which should be set
+// before plotting:
+//  fig.SetTheme(canvas.GGPlotTheme())
+//
+// Line widths, marker sizes and font sizes are defined in points.
+// A Figure with a physical size renders with the same proportions at
+// any DPI:
+//  fig, err := canvas.NewFigureInches(6, 4, 200)
+//
+// Once every plot is attached, the Axes can be fitted to the size of
+// their text with:
+//  fig.TightLayout(pad)
+//
+// A Figure can be plotted from a goroutine while another renders it.
+// The methods that attach, plot or change elements lock the Figure, and
+// so does plt.Render while drawing it. Exported fields are changed
+// holding the lock:
+//  fig.Lock()
+//  legend.Origin = [2]float64{0, 1}
+//  fig.Unlock()
+// The data limits and the location of an Axes are derived from its
+// fields when it changes, so they are set with SetXLim, SetYLim and the
+// layout methods, such as TightLayout, rather than through XLim, YLim,
+// Origin or Size.
 //
 // Canvas uses a primitive as the building block of the plotter.
 // A primitive implements Container and holds all the information
@@ -0,0 +1,178 @@
+package canvas
+
+import (
+	"math"
+)
+
+// DownsampleMethod defines how the points of a Line are reduced before
+// it is drawn.
+type DownsampleMethod byte
+
+const (
+	// NoDownsample draws every point of the Line.
+	NoDownsample DownsampleMethod = iota
+	// M4 keeps the first, last, lowest and highest point of each pixel
+	// column crossed by the Line. An opaque Line covers the same pixels
+	// as with every point.
+	M4
+	// MinMax keeps the lowest and highest point of each pixel column
+	// crossed by the Line.
+	MinMax
+	// LTTB keeps two points per pixel column chosen with the
+	// Largest-Triangle-Three-Buckets algorithm, which follows the shape
+	// of the Line but may smooth narrow peaks.
+	LTTB
+)
+
+// DefaultDownsampleThreshold is the number of points above which a Line
+// is downsampled with M4 unless the option Downsample says otherwise.
+const DefaultDownsampleThreshold = 5000
+
+// downsampleConfig holds the downsampling requested for a Line.
+type downsampleConfig struct {
+	set       bool
+	method    DownsampleMethod
+	threshold int
+}
+
+// Downsample sets the method used to reduce the points of a Line with
+// more than threshold points before it is drawn.
+// Downsample(NoDownsample, 0) draws every point.
+func Downsample(method DownsampleMethod, threshold int) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.downsample = downsampleConfig{true, method, threshold}
+	}
+}
+
+// column returns the pixel column of x, with every column outside of
+// [left, right) folded into the column next to the range.
+func column(x float64, left, right int) int {
+	c := int(x)
+	if c < left {
+		return left - 1
+	}
+	if c >= right {
+		return right
+	}
+	return c
+}
+
+// columns calls fn with the start and end, not included, of each run of
+// consecutive points of X in the same pixel column.
+func columns(X []float64, left, right int, fn func(start, end int)) {
+	start := 0
+	for i := 1; i <= len(X); i++ {
+		if i == len(X) || column(X[i], left, right) != column(X[start], left, right) {
+			fn(start, i)
+			start = i
+		}
+	}
+}
+
+// extremes returns the index of the lowest and highest values of Y
+// between start and end, not included.
+func extremes(Y []float64, start, end int) (lo, hi int) {
+	lo, hi = start, start
+	for i := start + 1; i < end; i++ {
+		if Y[i] < Y[lo] {
+			lo = i
+		}
+		if Y[i] > Y[hi] {
+			hi = i
+		}
+	}
+	return lo, hi
+}
+
+// downsampleM4 returns the first, last, lowest and highest points in
+// pixels of each column between left and right, in their original order.
+func downsampleM4(X, Y []float64, left, right int) (DX, DY []float64) {
+	keep := func(i int) {
+		DX = append(DX, X[i])
+		DY = append(DY, Y[i])
+	}
+	columns(X, left, right, func(start, end int) {
+		lo, hi := extremes(Y, start, end)
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		keep(start)
+		if lo > start && lo < end-1 {
+			keep(lo)
+		}
+		if hi > lo && hi < end-1 {
+			keep(hi)
+		}
+		if end-1 > start {
+			keep(end - 1)
+		}
+	})
+	return DX, DY
+}
+
+// downsampleMinMax returns the lowest and highest points in pixels of
+// each column between left and right, in their original order.
+func downsampleMinMax(X, Y []float64, left, right int) (DX, DY []float64) {
+	columns(X, left, right, func(start, end int) {
+		lo, hi := extremes(Y, start, end)
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		DX = append(DX, X[lo])
+		DY = append(DY, Y[lo])
+		if hi != lo {
+			DX = append(DX, X[hi])
+			DY = append(DY, Y[hi])
+		}
+	})
+	return DX, DY
+}
+
+// downsampleLTTB returns n points in pixels of X and Y selected with the
+// Largest-Triangle-Three-Buckets algorithm. The first and last points
+// are always kept.
+func downsampleLTTB(X, Y []float64, n int) (DX, DY []float64) {
+	if n >= len(X) || n < 3 {
+		return X, Y
+	}
+
+	DX = append(make([]float64, 0, n), X[0])
+	DY = append(make([]float64, 0, n), Y[0])
+	// The points between the first and the last are split into n-2
+	// buckets, and each bucket keeps the point forming the largest
+	// triangle with the point kept before it and the average of the
+	// next bucket.
+	size := float64(len(X)-2) / float64(n-2)
+	a := 0
+	for b := 0; b < n-2; b++ {
+		start := int(float64(b)*size) + 1
+		end := int(float64(b+1)*size) + 1
+
+		next0, next1 := end, min(int(float64(b+2)*size)+1, len(X))
+		if b == n-3 {
+			next0, next1 = len(X)-1, len(X)
+		}
+		var cx, cy float64
+		for i := next0; i < next1; i++ {
+			cx += X[i]
+			cy += Y[i]
+		}
+		cx /= float64(next1 - next0)
+		cy /= float64(next1 - next0)
+
+		best, area := start, -1.0
+		for i := start; i < end; i++ {
+			s := math.Abs((X[a]-cx)*(Y[i]-Y[a]) - (X[a]-X[i])*(cy-Y[a]))
+			if s > area {
+				best, area = i, s
+			}
+		}
+		DX = append(DX, X[best])
+		DY = append(DY, Y[best])
+		a = best
+	}
+
+	DX = append(DX, X[len(X)-1])
+	DY = append(DY, Y[len(Y)-1])
+	return DX, DY
+}
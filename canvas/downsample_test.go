@@ -0,0 +1,151 @@
+package canvas
+
+import (
+	"bytes"
+	"image"
+	"math"
+	"testing"
+)
+
+// seriesLine returns the Line of a 1000 pixels wide Figure plotting n
+// noisy points with a single peak at a third of the series.
+func seriesLine(tb testing.TB, n int, opts ...PlotOption) *Line {
+	fig, err := NewFigure(1000, 400)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	X := make([]float64, n)
+	Y := make([]float64, n)
+	for i := range X {
+		X[i] = float64(i)
+		Y[i] = math.Sin(float64(i)/5000) + 0.1*math.Sin(float64(i)*1.7)
+	}
+	Y[n/3] = 5
+	if err := ax.LinePlot(X, Y, opts...); err != nil {
+		tb.Fatal(err)
+	}
+	for _, c := range ax.children {
+		if l, ok := c.(*Line); ok {
+			return l
+		}
+	}
+	return nil
+}
+
+// renderLine draws the Line clipped to its Axes.
+func renderLine(l *Line) *image.RGBA {
+	dst := image.NewRGBA(l.Parent.Parent.Bounds())
+	l.Render(Clip(dst, l.Parent.Bounds()))
+	return dst
+}
+
+// top returns the highest pixel row reached by Y.
+func top(Y []float64) int {
+	m := math.Inf(1)
+	for _, y := range Y {
+		m = math.Min(m, y)
+	}
+	return int(m)
+}
+
+func TestDownsampleM4Pixels(t *testing.T) {
+	l := seriesLine(t, 100000)
+	if l.Downsample != M4 || l.Threshold != DefaultDownsampleThreshold {
+		t.Fatalf("Downsample %v, Threshold %v by default", l.Downsample, l.Threshold)
+	}
+	X, _ := l.pixels()
+	w := l.Parent.Bounds().Dx()
+	if len(X) > 4*(w+2) {
+		t.Errorf("M4 kept %v points for %v columns", len(X), w)
+	}
+
+	got := renderLine(l)
+	l.Downsample = NoDownsample
+	want := renderLine(l)
+	if !bytes.Equal(got.Pix, want.Pix) {
+		t.Error("M4 does not draw the pixels of the full Line")
+	}
+}
+
+func TestDownsamplePeaks(t *testing.T) {
+	full := seriesLine(t, 100000, Downsample(NoDownsample, 0))
+	_, Y := full.pixels()
+	peak := top(Y)
+
+	w := full.Parent.Bounds().Dx()
+	tests := []struct {
+		method DownsampleMethod
+		max    int
+	}{
+		{M4, 4 * (w + 2)},
+		{MinMax, 2 * (w + 2)},
+		{LTTB, 2 * (w + 1)},
+	}
+	for _, tt := range tests {
+		l := seriesLine(t, 100000, Downsample(tt.method, 1000))
+		X, Y := l.pixels()
+		if len(X) > tt.max {
+			t.Errorf("Method %v kept %v points, want at most %v", tt.method, len(X), tt.max)
+		}
+		if p := top(Y); p != peak {
+			t.Errorf("Method %v reaches row %v, want the peak at row %v", tt.method, p, peak)
+		}
+	}
+}
+
+func TestDownsampleThreshold(t *testing.T) {
+	tests := []struct {
+		n    int
+		opts []PlotOption
+		want int
+	}{
+		{DefaultDownsampleThreshold, nil, DefaultDownsampleThreshold},
+		{20000, []PlotOption{Downsample(M4, 50000)}, 20000},
+		{20000, []PlotOption{Downsample(NoDownsample, 0)}, 20000},
+	}
+	for _, tt := range tests {
+		l := seriesLine(t, tt.n, tt.opts...)
+		if X, _ := l.pixels(); len(X) != tt.want {
+			t.Errorf("%v points with %v options drawn with %v points, want %v",
+				tt.n, len(tt.opts), len(X), tt.want)
+		}
+	}
+}
+
+// TestDownsampleZoom keeps the pixels of the full Line when most of its
+// points are outside of the Axes.
+func TestDownsampleZoom(t *testing.T) {
+	l := seriesLine(t, 100000)
+	l.Parent.SetXLim(30000, 35000)
+	X, _ := l.pixels()
+	if len(X) > 4*(l.Parent.Bounds().Dx()+2) {
+		t.Errorf("M4 kept %v points", len(X))
+	}
+
+	got := renderLine(l)
+	l.Downsample = NoDownsample
+	want := renderLine(l)
+	if !bytes.Equal(got.Pix, want.Pix) {
+		t.Error("M4 does not draw the pixels of the full Line")
+	}
+}
+
+// The render benchmarks draw a week of metrics sampled every second,
+// 600k points, into a 1000 pixels wide Figure.
+
+func benchmarkRenderLine(b *testing.B, method DownsampleMethod) {
+	l := seriesLine(b, 600000, Downsample(method, DefaultDownsampleThreshold))
+	dst := image.NewRGBA(l.Parent.Parent.Bounds())
+	clip := Clip(dst, l.Parent.Bounds())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Render(clip)
+	}
+}
+
+func BenchmarkRenderLineM4(b *testing.B)     { benchmarkRenderLine(b, M4) }
+func BenchmarkRenderLineMinMax(b *testing.B) { benchmarkRenderLine(b, MinMax) }
+func BenchmarkRenderLineLTTB(b *testing.B)   { benchmarkRenderLine(b, LTTB) }
+func BenchmarkRenderLineFull(b *testing.B)   { benchmarkRenderLine(b, NoDownsample) }
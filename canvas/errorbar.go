@@ -0,0 +1,149 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+// ErrorBar represents the uncertainty of a single data point
+// with Axes as its parent.
+// The errors are located in data coordinates while the width
+// and caps are defined in points.
+type ErrorBar struct {
+	primitive
+	Parent *Axes
+	X, Y   float64
+	// Xerr and Yerr hold the lower and upper errors.
+	Xerr, Yerr [2]float64
+	// W is the width of the bars and Cap the length of the caps in points.
+	W, Cap int
+}
+
+func (e *ErrorBar) String() string {
+	return fmt.Sprintf("ErrorBar {X: %v, Y: %v, Xerr: %v, Yerr: %v}", e.X, e.Y, e.Xerr, e.Yerr)
+}
+
+// newErrorBar creates a new ErrorBar at (x, y) in data coordinates
+// linked to an Axes.
+func newErrorBar(parent *Axes, x, y float64, xerr, yerr [2]float64) (*ErrorBar, error) {
+	var e ErrorBar
+	e.Parent = parent
+	e.X = x
+	e.Y = y
+	e.Xerr = xerr
+	e.Yerr = yerr
+	e.W = 2
+	e.Cap = 6
+	e.t = parent.dataT
+	e.FillColor = parent.Parent.theme.EdgeColor
+
+	parent.children = append(parent.children, &e)
+	return &e, nil
+}
+
+// Render draws the bars and caps of the ErrorBar into a draw.Image interface.
+func (e *ErrorBar) Render(dst draw.Image) {
+	src := &image.Uniform{e.Color()}
+	f := e.Parent.Parent
+	W, Cap := f.pxi(e.W), f.pxi(e.Cap)
+	w := W / 2
+	c := Cap / 2
+
+	if e.Xerr != [2]float64{} {
+		x0, y := pixel(e, e.X-e.Xerr[0], e.Y)
+		x1, _ := pixel(e, e.X+e.Xerr[1], e.Y)
+		X0, X1, Y := int(x0), int(x1), int(y)
+		draw.Draw(dst, image.Rect(X0, Y-w, X1, Y+W-w), src, image.ZP, draw.Over)
+		if Cap > 0 {
+			draw.Draw(dst, image.Rect(X0-w, Y-c, X0+W-w, Y+Cap-c), src, image.ZP, draw.Over)
+			draw.Draw(dst, image.Rect(X1-w, Y-c, X1+W-w, Y+Cap-c), src, image.ZP, draw.Over)
+		}
+	}
+
+	if e.Yerr != [2]float64{} {
+		x, y0 := pixel(e, e.X, e.Y-e.Yerr[0])
+		_, y1 := pixel(e, e.X, e.Y+e.Yerr[1])
+		X, Y0, Y1 := int(x), int(y0), int(y1)
+		draw.Draw(dst, image.Rect(X-w, Y1, X+W-w, Y0), src, image.ZP, draw.Over)
+		if Cap > 0 {
+			draw.Draw(dst, image.Rect(X-c, Y0-w, X+Cap-c, Y0+W-w), src, image.ZP, draw.Over)
+			draw.Draw(dst, image.Rect(X-c, Y1-w, X+Cap-c, Y1+W-w), src, image.ZP, draw.Over)
+		}
+	}
+}
+
+// errorBars attaches the error bars requested in cfg to the points X and Y.
+func (ax *Axes) errorBars(X, Y []float64, cfg *plotConfig) error {
+	xl, xu, err := bounds(cfg.xerr, len(X))
+	if err != nil {
+		return err
+	}
+	yl, yu, err := bounds(cfg.yerr, len(Y))
+	if err != nil {
+		return err
+	}
+	if xl == nil && yl == nil {
+		return nil
+	}
+
+	for i := range X {
+		var xerr, yerr [2]float64
+		if xl != nil {
+			xerr = [2]float64{xl[i], xu[i]}
+		}
+		if yl != nil {
+			yerr = [2]float64{yl[i], yu[i]}
+		}
+		e, err := newErrorBar(ax, X[i], Y[i], xerr, yerr)
+		if err != nil {
+			return err
+		}
+		e.Cap = cfg.capSize
+	}
+
+	return nil
+}
+
+// ErrorBar creates an error bar chart inside Axes with X and Y values
+// and the symmetric vertical errors Yerr.
+// Asymmetric or horizontal errors can be set with the options YErr and XErr,
+// which take precedence over Yerr.
+func (ax *Axes) ErrorBar(X, Y, Yerr []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if len(X) != len(Y) {
+		return fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
+	}
+	var base []PlotOption
+	if Yerr != nil {
+		base = append(base, YErr(Yerr))
+	}
+	cfg := newPlotConfig(append(base, opts...))
+	if err := cfg.checkErrors(X, Y); err != nil {
+		return err
+	}
+
+	c := cfg.colorOr(ax.nextColor())
+	for i := range Y {
+		p, err := newScatterPoint(ax, X[i], Y[i])
+		if err != nil {
+			return err
+		}
+		p.FillColor = c
+	}
+	ax.addEntry(cfg, c, legendMarker)
+
+	if err := ax.errorBars(X, Y, cfg); err != nil {
+		return err
+	}
+
+	ax.extend(cfg.extentX(X), cfg.extentY(Y))
+
+	ax.side(BottomAxis)
+	ax.side(LeftAxis)
+
+	return nil
+}
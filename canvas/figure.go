@@ -1,50 +1,142 @@
 package canvas
 
 import (
-	"golang.org/x/image/colornames"
-	"gonum.org/v1/gonum/mat"
+	"fmt"
+	"math"
+	"sync"
 )
 
+// DefaultDPI is the resolution of a Figure created with NewFigure.
+// At 72 DPI a point is a pixel.
+const DefaultDPI = 72
+
+// Millimeters per inch.
+const mmPerInch = 25.4
+
 // Figure defines the basic area to draw all the elements of
 // the plot.
 // This is the top parent container.
 type Figure struct {
 	primitive
+	// tc maps the coordinates of the children, from (0, 0) at the lower
+	// left corner to (1, 1) at the upper right corner, into the Figure.
+	tc *transform
+	// DPI is the number of pixels per inch of the Figure.
+	// Line widths, marker sizes, tick lengths and font sizes are defined
+	// in points, 1/72 of an inch, so the Figure keeps its proportions
+	// at any DPI.
+	DPI float64
+
+	specs []*GridSpec
+	theme *Theme
+	// tight is set once TightLayout is used, with pad its padding
+	// in points, so the layout runs again when the Figure is resized.
+	tight bool
+	pad   int
+
+	// mu guards every change to the Figure and its elements.
+	mu sync.Mutex
+}
+
+// Lock locks the Figure and every element attached to it.
+//
+// The methods that attach, plot or change elements lock the Figure
+// themselves, and so do plt.Render and plt.RenderParallel for the whole
+// rendering, so a Figure can be plotted from a goroutine while another
+// renders it. Each call is applied as a whole, but a render may happen
+// between two calls.
+//
+// Hold the lock to change the exported fields of the elements directly,
+// such as XLim or Origin, or to make several calls appear at once to
+// a concurrent render. The methods of the Figure must not be called
+// while holding the lock.
+func (f *Figure) Lock() {
+	f.mu.Lock()
 }
 
-// Resize changes the width and height of the Figure.
-// It also updates the transformation matrix of Figure.
+// Unlock unlocks the Figure.
+func (f *Figure) Unlock() {
+	f.mu.Unlock()
+}
+
+// Resize changes the width and height of the Figure in pixels and lays
+// out its contents again, so the result equals building the Figure at
+// the new size: the GridSpecs place their Axes, TightLayout runs again
+// if it was used and the Axes with an equal aspect recompute their
+// limits. Ticks and fonts are sized from the new layout when rendered.
+//
+// The transformations of the Figure are the root of the transformations
+// of every element, so the whole tree follows the new size.
 func (f *Figure) Resize(w, h float64) {
+	f.Lock()
+	defer f.Unlock()
+	f.resize(w, h)
+}
+
+// resize changes the size of the Figure and lays out its contents.
+func (f *Figure) resize(w, h float64) {
 	f.Size = [2]float64{w, h}
-	f.T[1].Set(0, 0, w)
-	f.T[1].Set(1, 1, h)
+	f.tc.set(ScaleTranslate(w, h, 0, 0))
+
+	f.t.set(ScaleTranslate(1, -1, 0, h))
 
-	f.T[0].Set(1, 2, h)
+	f.relayout()
+}
+
+// relayout places every Axes of the Figure again from its GridSpec
+// and recomputes the limits and ticks of each Axes.
+func (f *Figure) relayout() {
+	for _, gs := range f.specs {
+		gs.layout()
+	}
+	if f.tight {
+		f.tightLayout(f.pad)
+	}
+	for _, c := range f.children {
+		switch ax := c.(type) {
+		case *Axes:
+			ax.update()
+		case *PolarAxes:
+			ax.cart.update()
+		}
+	}
 }
 
 // NewAxes attaches a new Axes into the Figure.
 func (f *Figure) NewAxes() *Axes {
-	axes, _ := f.SubAxes(1, 1)
+	f.Lock()
+	defer f.Unlock()
+	axes, _ := f.subAxes(1, 1)
 	return axes[0]
 }
 
 // SubAxes attaches multiple Axes defined by the number of rows and columns.
-func (f *Figure) SubAxes(rows, cols int) ([]*Axes, error) {
-	var axes []*Axes
+// The Axes can share their limits with the options ShareX and ShareY.
+func (f *Figure) SubAxes(rows, cols int, opts ...GridOption) ([]*Axes, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.subAxes(rows, cols, opts...)
+}
 
-	n := float64(cols)
-	var padX, padY float64 = 0.12, 0.08
+// subAxes attaches the Axes of SubAxes.
+func (f *Figure) subAxes(rows, cols int, opts ...GridOption) ([]*Axes, error) {
+	var axes []*Axes
 
-	spaceW := padX
-	axW := (1 - 2*padX - (n-1)*spaceW) / n
+	gs, err := f.addGridSpec(rows, cols, opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	n = float64(rows)
-	spaceH := padY
-	axH := (1 - 2*padY - (n-1)*spaceH) / n
+	// Keep the space between Axes as wide as the padding of the Figure.
+	var padX, padY float64 = 0.12, 0.08
+	axW := (1 - 2*padX - float64(cols-1)*padX) / float64(cols)
+	axH := (1 - 2*padY - float64(rows-1)*padY) / float64(rows)
+	gs.region = [4]float64{padX, padY, 1 - 2*padX, 1 - 2*padY}
+	gs.WSpace, gs.HSpace = padX/axW, padY/axH
 
-	for j := rows - 1; j >= 0; j-- {
+	for j := 0; j < rows; j++ {
 		for i := 0; i < cols; i++ {
-			ax, err := newAxes(f, padX+float64(i)*(axW+spaceW), padY+float64(j)*(axH+spaceH), axW, axH)
+			ax, err := gs.axes(j, j+1, i, i+1)
 			if err != nil {
 				return nil, err
 			}
@@ -59,21 +151,76 @@ func (f *Figure) SubAxes(rows, cols int) ([]*Axes, error) {
 func NewFigure(w, h int) (*Figure, error) {
 	max := [2]float64{float64(w), float64(h)}
 
-	T := mat.NewDense(3, 3, []float64{
-		1, 0, 0,
-		0, -1, max[1],
-		0, 0, 1,
-	})
-	Tc := mat.NewDense(3, 3, []float64{
-		max[0], 0, 0,
-		0, max[1], 0,
-		0, 0, 1,
-	})
-
 	var fig Figure
-	fig.T = append(fig.T, T, Tc)
+	// The pixels of an image grow downwards.
+	fig.t = newTransform(nil, ScaleTranslate(1, -1, 0, max[1]))
+	fig.tc = newTransform(fig.t, ScaleTranslate(max[0], max[1], 0, 0))
+	fig.DPI = DefaultDPI
 	fig.Resize(max[0], max[1])
-	fig.FillColor = colornames.Gainsboro
+	fig.theme = DefaultTheme()
+	fig.FillColor = fig.theme.FigureColor
 
 	return &fig, nil
 }
+
+// NewFigureInches creates a new *Figure with width and height in inches
+// rendered at dpi pixels per inch.
+func NewFigureInches(w, h, dpi float64) (*Figure, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("Size (%v, %v) not valid", w, h)
+	}
+	if dpi <= 0 {
+		return nil, fmt.Errorf("DPI %v not valid", dpi)
+	}
+	fig, err := NewFigure(int(math.Round(w*dpi)), int(math.Round(h*dpi)))
+	if err != nil {
+		return nil, err
+	}
+	fig.DPI = dpi
+	return fig, nil
+}
+
+// NewFigureMM creates a new *Figure with width and height in millimeters
+// rendered at dpi pixels per inch.
+func NewFigureMM(w, h, dpi float64) (*Figure, error) {
+	return NewFigureInches(w/mmPerInch, h/mmPerInch, dpi)
+}
+
+// SetDPI changes the resolution of the Figure keeping its physical size,
+// so its width and height in pixels scale with dpi.
+func (f *Figure) SetDPI(dpi float64) error {
+	if dpi <= 0 {
+		return fmt.Errorf("DPI %v not valid", dpi)
+	}
+	f.Lock()
+	defer f.Unlock()
+	w, h := f.inches()
+	f.DPI = dpi
+	f.resize(math.Round(w*dpi), math.Round(h*dpi))
+	return nil
+}
+
+// Inches returns the physical width and height of the Figure in inches.
+func (f *Figure) Inches() (w, h float64) {
+	f.Lock()
+	defer f.Unlock()
+	return f.inches()
+}
+
+func (f *Figure) inches() (w, h float64) {
+	return f.Size[0] / f.DPI, f.Size[1] / f.DPI
+}
+
+// px returns the length in pixels of p points at the DPI of the Figure.
+func (f *Figure) px(p float64) float64 {
+	return p * f.DPI / 72
+}
+
+// pxi returns the length in whole pixels of p points at the DPI of the
+// Figure. Positive lengths are at least a pixel wide.
+func (f *Figure) pxi(p int) int {
+	if p <= 0 {
+		return p
+	}
+	return max(1, int(math.Round(f.px(float64(p)))))
+}
@@ -0,0 +1,228 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/vector"
+)
+
+// Polygon represents a filled shape in data coordinates with Axes as its parent.
+type Polygon struct {
+	primitive
+	Parent *Axes
+	X, Y   []float64
+}
+
+func (p *Polygon) String() string {
+	return fmt.Sprintf("Polygon {Vertices: %v, Bounds: %v}", len(p.X), p.Bounds())
+}
+
+// newPolygon creates a new Polygon with vertices X and Y linked to an Axes.
+func newPolygon(parent *Axes, X, Y []float64) (*Polygon, error) {
+	if len(X) != len(Y) {
+		return &Polygon{}, fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
+	}
+
+	var p Polygon
+	p.Parent = parent
+	p.X = X
+	p.Y = Y
+	p.t = parent.dataT
+	p.FillColor = parent.Parent.theme.color(0)
+
+	parent.children = append(parent.children, &p)
+	return &p, nil
+}
+
+// pixels returns the vertices of the Polygon in pixels.
+func (p *Polygon) pixels() (X, Y []float64) {
+	X = make([]float64, len(p.X))
+	Y = make([]float64, len(p.Y))
+	for i := range p.X {
+		X[i], Y[i] = pixel(p, p.X[i], p.Y[i])
+	}
+	return X, Y
+}
+
+// Bounds returns the rectangle in pixels that contains the Polygon.
+func (p *Polygon) Bounds() image.Rectangle {
+	X, Y := p.pixels()
+	if len(X) == 0 {
+		return image.Rectangle{}
+	}
+	return image.Rect(
+		int(math.Floor(minSlice(X))), int(math.Floor(minSlice(Y))),
+		int(math.Ceil(maxSlice(X))), int(math.Ceil(maxSlice(Y))),
+	)
+}
+
+// Render fills the Polygon into a draw.Image interface.
+func (p *Polygon) Render(dst draw.Image) {
+	if len(p.X) < 3 {
+		return
+	}
+	X, Y := p.pixels()
+	fillPixels(dst, X, Y, p.Color())
+}
+
+// fillPixels fills the shape with vertices X and Y in pixels with c.
+func fillPixels(dst draw.Image, X, Y []float64, c color.Color) {
+	if len(X) < 3 {
+		return
+	}
+	r := image.Rect(
+		int(math.Floor(minSlice(X))), int(math.Floor(minSlice(Y))),
+		int(math.Ceil(maxSlice(X))), int(math.Ceil(maxSlice(Y))),
+	).Intersect(dst.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	z := vector.NewRasterizer(r.Dx(), r.Dy())
+	z.MoveTo(float32(X[0])-float32(r.Min.X), float32(Y[0])-float32(r.Min.Y))
+	for i := 1; i < len(X); i++ {
+		z.LineTo(float32(X[i])-float32(r.Min.X), float32(Y[i])-float32(r.Min.Y))
+	}
+	z.ClosePath()
+	z.Draw(dst, r, &image.Uniform{c}, image.ZP)
+}
+
+// fillRegions returns the outlines of the areas between Y1 and Y2
+// where the mask is true.
+// If interpolate is true, each area is extended up to the point where
+// Y1 and Y2 cross.
+func fillRegions(X, Y1, Y2 []float64, where []bool, interpolate bool) (Xs, Ys [][]float64) {
+	var x, top, bottom []float64
+
+	closeRegion := func() {
+		if len(x) > 1 {
+			px := make([]float64, 0, 2*len(x))
+			py := make([]float64, 0, 2*len(x))
+			px = append(px, x...)
+			py = append(py, top...)
+			for i := len(x) - 1; i >= 0; i-- {
+				px = append(px, x[i])
+				py = append(py, bottom[i])
+			}
+			Xs = append(Xs, px)
+			Ys = append(Ys, py)
+		}
+		x, top, bottom = nil, nil, nil
+	}
+
+	// crossing returns the point between i and i+1 where Y1 and Y2 meet.
+	crossing := func(i int) (float64, float64) {
+		d0 := Y1[i] - Y2[i]
+		d1 := Y1[i+1] - Y2[i+1]
+		if d0 == d1 {
+			return X[i], Y1[i]
+		}
+		t := d0 / (d0 - d1)
+		return X[i] + t*(X[i+1]-X[i]), Y1[i] + t*(Y1[i+1]-Y1[i])
+	}
+
+	for i := range X {
+		if where != nil && !where[i] {
+			continue
+		}
+
+		if interpolate && where != nil && i > 0 && !where[i-1] {
+			xc, yc := crossing(i - 1)
+			x, top, bottom = append(x, xc), append(top, yc), append(bottom, yc)
+		}
+
+		x, top, bottom = append(x, X[i]), append(top, Y1[i]), append(bottom, Y2[i])
+
+		if where != nil && (i == len(X)-1 || !where[i+1]) {
+			if interpolate && i < len(X)-1 {
+				xc, yc := crossing(i)
+				x, top, bottom = append(x, xc), append(top, yc), append(bottom, yc)
+			}
+			closeRegion()
+		}
+	}
+	closeRegion()
+
+	return Xs, Ys
+}
+
+// FillBetween fills the area between the curves (X, Y1) and (X, Y2)
+// inside Axes.
+// The filled regions can be restricted with the option Where and
+// extended to the crossing of the curves with the option Interpolate.
+func (ax *Axes) FillBetween(X, Y1, Y2 []float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.fillBetween(X, Y1, Y2, opts...)
+}
+
+func (ax *Axes) fillBetween(X, Y1, Y2 []float64, opts ...PlotOption) error {
+	if len(X) != len(Y1) || len(X) != len(Y2) {
+		return fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y1[%v] != Y2[%v])",
+			len(X), len(Y1), len(Y2))
+	}
+	cfg := newPlotConfig(opts)
+	if cfg.where != nil && len(cfg.where) != len(X) {
+		return fmt.Errorf(
+			"Dimensions mismatch (X[%v] != where[%v])",
+			len(X), len(cfg.where))
+	}
+
+	c := cfg.colorOr(ax.nextColor())
+	Xs, Ys := fillRegions(X, Y1, Y2, cfg.where, cfg.interpolate)
+	for i := range Xs {
+		p, err := newPolygon(ax, Xs[i], Ys[i])
+		if err != nil {
+			return err
+		}
+		p.FillColor = c
+	}
+	ax.addEntry(cfg, c, legendPatch)
+
+	ax.extend(X, Y1)
+	ax.extend(nil, Y2)
+
+	ax.side(BottomAxis)
+	ax.side(LeftAxis)
+
+	return nil
+}
+
+// StackPlot draws each slice of Ys as a filled area stacked on top of
+// the previous ones inside Axes.
+// Each layer takes the next color of the Palette unless the option Color
+// is set. The options apply to every layer, and LegendLabel labels the
+// stack once with the color of its first layer.
+func (ax *Axes) StackPlot(X []float64, Ys [][]float64, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	base := make([]float64, len(X))
+	for l, Y := range Ys {
+		if len(Y) != len(X) {
+			return fmt.Errorf(
+				"Dimensions mismatch (X[%v] != Y[%v])",
+				len(X), len(Y))
+		}
+		top := make([]float64, len(X))
+		for i := range Y {
+			top[i] = base[i] + Y[i]
+		}
+		layer := opts
+		if l > 0 {
+			layer = append(opts[:len(opts):len(opts)], LegendLabel(""))
+		}
+		if err := ax.fillBetween(X, base, top, layer...); err != nil {
+			return err
+		}
+		base = top
+	}
+
+	return nil
+}
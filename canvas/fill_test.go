@@ -0,0 +1,116 @@
+package canvas
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestFillRegions(t *testing.T) {
+	tests := []struct {
+		name        string
+		Y1, Y2      []float64
+		where       []bool
+		interpolate bool
+		Xs, Ys      [][]float64
+	}{
+		{
+			name: "no mask",
+			Y1:   []float64{0, 2, 2, 0}, Y2: []float64{1, 1, 1, 1},
+			Xs: [][]float64{{0, 1, 2, 3, 3, 2, 1, 0}},
+			Ys: [][]float64{{0, 2, 2, 0, 1, 1, 1, 1}},
+		},
+		{
+			name: "no mask interpolated",
+			Y1:   []float64{0, 2, 2, 0}, Y2: []float64{1, 1, 1, 1},
+			interpolate: true,
+			Xs:          [][]float64{{0, 1, 2, 3, 3, 2, 1, 0}},
+			Ys:          [][]float64{{0, 2, 2, 0, 1, 1, 1, 1}},
+		},
+		{
+			name: "mask",
+			Y1:   []float64{0, 2, 2, 0}, Y2: []float64{1, 1, 1, 1},
+			where: []bool{false, true, true, false},
+			Xs:    [][]float64{{1, 2, 2, 1}},
+			Ys:    [][]float64{{2, 2, 1, 1}},
+		},
+		{
+			name: "mask interpolated",
+			Y1:   []float64{0, 2, 2, 0}, Y2: []float64{1, 1, 1, 1},
+			where:       []bool{false, true, true, false},
+			interpolate: true,
+			Xs:          [][]float64{{0.5, 1, 2, 2.5, 2.5, 2, 1, 0.5}},
+			Ys:          [][]float64{{1, 2, 2, 1, 1, 1, 1, 1}},
+		},
+		{
+			name: "two regions",
+			Y1:   []float64{2, 0, 2, 2}, Y2: []float64{1, 1, 1, 1},
+			where: []bool{true, false, true, true},
+			Xs:    [][]float64{{2, 3, 3, 2}},
+			Ys:    [][]float64{{2, 2, 1, 1}},
+		},
+		{
+			name: "two regions interpolated",
+			Y1:   []float64{2, 0, 2, 2}, Y2: []float64{1, 1, 1, 1},
+			where:       []bool{true, false, true, true},
+			interpolate: true,
+			Xs:          [][]float64{{0, 0.5, 0.5, 0}, {1.5, 2, 3, 3, 2, 1.5}},
+			Ys:          [][]float64{{2, 1, 1, 1}, {1, 2, 2, 1, 1, 1}},
+		},
+		{
+			name: "empty mask",
+			Y1:   []float64{0, 2, 2, 0}, Y2: []float64{1, 1, 1, 1},
+			where:       []bool{false, false, false, false},
+			interpolate: true,
+		},
+	}
+
+	X := []float64{0, 1, 2, 3}
+	for _, tt := range tests {
+		Xs, Ys := fillRegions(X, tt.Y1, tt.Y2, tt.where, tt.interpolate)
+		if !reflect.DeepEqual(Xs, tt.Xs) || !reflect.DeepEqual(Ys, tt.Ys) {
+			t.Errorf("%v: got %v %v, want %v %v", tt.name, Xs, Ys, tt.Xs, tt.Ys)
+		}
+	}
+}
+
+func TestStackPlotOptions(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	X := []float64{0, 1, 2}
+	Ys := [][]float64{{1, 2, 1}, {2, 1, 2}, {1, 1, 1}}
+	red := color.RGBA{255, 0, 0, 255}
+	if err := ax.StackPlot(X, Ys, Color(red), Alpha(0.5), LegendLabel("stack")); err != nil {
+		t.Fatal(err)
+	}
+
+	var layers []*Polygon
+	for _, c := range ax.children {
+		if p, ok := c.(*Polygon); ok {
+			layers = append(layers, p)
+		}
+	}
+	if len(layers) != len(Ys) {
+		t.Fatalf("%v layers, want %v", len(layers), len(Ys))
+	}
+	want := withAlpha(red, 0.5)
+	for i, p := range layers {
+		if p.FillColor != want {
+			t.Errorf("Layer %v filled with %v, want %v", i, p.FillColor, want)
+		}
+	}
+	// The top of the last layer is the sum of every layer.
+	if got := layers[2].Y[3:]; !reflect.DeepEqual(got, []float64{4, 4, 4}) {
+		t.Errorf("Stack top %v, want [4 4 4]", got)
+	}
+	if len(ax.entries) != 1 || ax.entries[0].label != "stack" {
+		t.Errorf("Legend entries %v, want a single stack entry", ax.entries)
+	}
+
+	if err := ax.StackPlot(X, [][]float64{{1, 2}}); err == nil {
+		t.Error("StackPlot accepted a layer of different length")
+	}
+}
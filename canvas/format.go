@@ -0,0 +1,215 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter turns the values of the ticks of an Axis into labels.
+// Besides the labels, a Formatter can return a text shared by all the
+// ticks, such as a multiplier, which is drawn once at the end of the Axis.
+type Formatter interface {
+	Format(values []float64) (labels []string, shared string)
+}
+
+// FormatterFunc formats each tick value on its own.
+// It allows any func(float64) string to be used as a Formatter.
+type FormatterFunc func(v float64) string
+
+// Format calls f for each value.
+func (f FormatterFunc) Format(values []float64) ([]string, string) {
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = f(v)
+	}
+	return labels, ""
+}
+
+// formatFixed formats v with a fixed number of decimals.
+// Values rounded to zero are formatted without their sign.
+func formatFixed(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if s[0] == '-' && strings.Trim(s, "-0.") == "" {
+		return s[1:]
+	}
+	return s
+}
+
+// rounded returns the value of a label formatted by formatFixed.
+func rounded(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// finite reports whether v is neither NaN nor infinite.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// FixedFormatter formats the ticks with a fixed number of decimals.
+func FixedFormatter(decimals int) Formatter {
+	return FormatterFunc(func(v float64) string {
+		return formatFixed(v, decimals)
+	})
+}
+
+// siPrefixes holds the SI prefixes for each power of 1000 from 10^-24.
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// EngFormatter formats the ticks in engineering notation with SI prefixes
+// followed by unit, for example "1.2 MHz".
+func EngFormatter(unit string, decimals int) Formatter {
+	return FormatterFunc(func(v float64) string {
+		e := 0
+		if v != 0 && finite(v) {
+			e = int(math.Floor(math.Log10(math.Abs(v)) / 3))
+		}
+		if e < -8 {
+			e = -8
+		}
+		if e > 8 {
+			e = 8
+		}
+		s := formatFixed(v/math.Pow(1000, float64(e)), decimals)
+		// Rounding can carry the value to the next prefix, as 999.99
+		// rounded to "1000.0".
+		if math.Abs(rounded(s)) >= 1000 && e < 8 {
+			e++
+			s = formatFixed(v/math.Pow(1000, float64(e)), decimals)
+		}
+		if suffix := siPrefixes[e+8] + unit; suffix != "" {
+			s += " " + suffix
+		}
+		return s
+	})
+}
+
+// sciFormatter formats the ticks in scientific notation.
+type sciFormatter struct {
+	decimals int
+}
+
+// SciFormatter formats the ticks in scientific notation.
+// The multiplier and, when the ticks are far from zero, the offset are
+// shared by all the ticks, for example "×1e-3 +1e+06".
+func SciFormatter(decimals int) Formatter {
+	return sciFormatter{decimals}
+}
+
+// Format returns the mantissa of each value and the shared
+// multiplier and offset.
+func (f sciFormatter) Format(values []float64) ([]string, string) {
+	if len(values) == 0 {
+		return nil, ""
+	}
+	// NaN and infinite values are labeled but do not scale the others.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if finite(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+
+	var offset float64
+	if span := hi - lo; span > 0 && math.Min(math.Abs(lo), math.Abs(hi)) > 1000*span {
+		step := math.Pow(10, math.Ceil(math.Log10(span)))
+		offset = math.Floor(lo/step) * step
+	}
+
+	var big float64
+	for _, v := range values {
+		if finite(v) {
+			big = math.Max(big, math.Abs(v-offset))
+		}
+	}
+	e := 0
+	if big > 0 {
+		e = int(math.Floor(math.Log10(big)))
+		// Rounding can carry the largest mantissa to 10.
+		if rounded(formatFixed(big/math.Pow10(e), f.decimals)) >= 10 {
+			e++
+		}
+	}
+
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = formatFixed((v-offset)/math.Pow10(e), f.decimals)
+	}
+
+	var shared []string
+	if e != 0 {
+		shared = append(shared, fmt.Sprintf("×1e%d", e))
+	}
+	if offset != 0 {
+		shared = append(shared, fmt.Sprintf("%+g", offset))
+	}
+	return labels, strings.Join(shared, " ")
+}
+
+// PercentFormatter formats the ticks as a percentage of max,
+// for example "45%".
+func PercentFormatter(max float64, decimals int) Formatter {
+	return FormatterFunc(func(v float64) string {
+		return formatFixed(100*v/max, decimals) + "%"
+	})
+}
+
+// CurrencyFormatter formats the ticks as an amount of money with
+// the currency symbol and thousands separators, for example "$1,200.00".
+func CurrencyFormatter(symbol string, decimals int) Formatter {
+	return FormatterFunc(func(v float64) string {
+		sign, s := "", formatFixed(v, decimals)
+		if s[0] == '-' {
+			sign, s = "-", s[1:]
+		}
+		integer, fraction := s, ""
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			integer, fraction = s[:i], s[i:]
+		}
+		for i := len(integer) - 3; i > 0; i -= 3 {
+			integer = integer[:i] + "," + integer[i:]
+		}
+		return sign + symbol + integer + fraction
+	})
+}
+
+// byteUnits holds the binary prefixes for each power of 1024.
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// BytesFormatter formats the ticks as sizes in bytes with binary prefixes,
+// for example "1.5 MiB".
+func BytesFormatter(decimals int) Formatter {
+	return FormatterFunc(func(v float64) string {
+		i := 0
+		for math.Abs(v) >= 1024 && i < len(byteUnits)-1 {
+			v /= 1024
+			i++
+		}
+		// Bytes are whole, and rounding can carry the value to the next
+		// prefix, as 1023.99 KiB rounded to "1024.0".
+		s := formatFixed(v, 0)
+		if i > 0 {
+			s = formatFixed(v, decimals)
+		}
+		if math.Abs(rounded(s)) >= 1024 && i < len(byteUnits)-1 {
+			v /= 1024
+			i++
+			s = formatFixed(v, decimals)
+		}
+		return s + " " + byteUnits[i]
+	})
+}
+
+// DurationFormatter formats the ticks as durations, for example "1m30s".
+// Each value is multiplied by unit, for example time.Second.
+func DurationFormatter(unit time.Duration) Formatter {
+	return FormatterFunc(func(v float64) string {
+		if !finite(v) {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return time.Duration(math.Round(v * float64(unit))).String()
+	})
+}
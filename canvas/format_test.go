@@ -0,0 +1,97 @@
+package canvas
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormatters(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name string
+		f    Formatter
+		v    float64
+		want string
+	}{
+		{"Fixed zero", FixedFormatter(2), 0, "0.00"},
+		{"Fixed", FixedFormatter(2), 3.14159, "3.14"},
+		{"Fixed negative", FixedFormatter(1), -2.25, "-2.2"},
+		{"Fixed negative zero", FixedFormatter(2), -0.001, "0.00"},
+		{"Fixed round up", FixedFormatter(0), 9.5, "10"},
+		{"Fixed NaN", FixedFormatter(2), nan, "NaN"},
+
+		{"Eng zero", EngFormatter("Hz", 1), 0, "0.0 Hz"},
+		{"Eng", EngFormatter("Hz", 1), 1234, "1.2 kHz"},
+		{"Eng negative", EngFormatter("V", 2), -0.0015, "-1.50 mV"},
+		{"Eng micro", EngFormatter("s", 0), 2e-6, "2 µs"},
+		{"Eng no unit", EngFormatter("", 1), 12, "12.0"},
+		{"Eng round up", EngFormatter("Hz", 1), 999.99, "1.0 kHz"},
+		{"Eng negative round up", EngFormatter("Hz", 1), -999999, "-1.0 MHz"},
+		{"Eng below round up", EngFormatter("Hz", 1), 999.94, "999.9 Hz"},
+		{"Eng largest prefix", EngFormatter("B", 0), 2e30, "2000000 YB"},
+		{"Eng NaN", EngFormatter("Hz", 1), nan, "NaN Hz"},
+
+		{"Percent zero", PercentFormatter(1, 0), 0, "0%"},
+		{"Percent", PercentFormatter(200, 1), 90, "45.0%"},
+		{"Percent negative", PercentFormatter(1, 0), -0.25, "-25%"},
+		{"Percent round up", PercentFormatter(1, 0), 0.995, "100%"},
+		{"Percent NaN", PercentFormatter(1, 0), nan, "NaN%"},
+
+		{"Currency zero", CurrencyFormatter("$", 2), 0, "$0.00"},
+		{"Currency", CurrencyFormatter("$", 2), 1200, "$1,200.00"},
+		{"Currency millions", CurrencyFormatter("€", 0), 1234567, "€1,234,567"},
+		{"Currency negative", CurrencyFormatter("$", 2), -1234.5, "-$1,234.50"},
+		{"Currency negative zero", CurrencyFormatter("$", 2), -0.001, "$0.00"},
+		{"Currency round up", CurrencyFormatter("$", 0), 999.5, "$1,000"},
+		{"Currency NaN", CurrencyFormatter("$", 2), nan, "$NaN"},
+
+		{"Bytes zero", BytesFormatter(1), 0, "0 B"},
+		{"Bytes", BytesFormatter(1), 1536, "1.5 KiB"},
+		{"Bytes negative", BytesFormatter(1), -3 * 1024 * 1024, "-3.0 MiB"},
+		{"Bytes whole", BytesFormatter(1), 1000.4, "1000 B"},
+		{"Bytes round up", BytesFormatter(1), 1023.6, "1.0 KiB"},
+		{"Bytes prefix round up", BytesFormatter(1), 1024*1024 - 1, "1.0 MiB"},
+		{"Bytes NaN", BytesFormatter(1), nan, "NaN B"},
+
+		{"Duration zero", DurationFormatter(time.Second), 0, "0s"},
+		{"Duration", DurationFormatter(time.Second), 90, "1m30s"},
+		{"Duration negative", DurationFormatter(time.Minute), -1.5, "-1m30s"},
+		{"Duration round", DurationFormatter(time.Second), 0.1 + 0.2, "300ms"},
+		{"Duration NaN", DurationFormatter(time.Second), nan, "NaN"},
+	}
+	for _, tt := range tests {
+		labels, shared := tt.f.Format([]float64{tt.v})
+		if len(labels) != 1 || labels[0] != tt.want || shared != "" {
+			t.Errorf("%v: Format(%v) = %q %q, want %q", tt.name, tt.v, labels, shared, tt.want)
+		}
+	}
+}
+
+func TestSciFormatter(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		labels []string
+		shared string
+	}{
+		{"empty", nil, nil, ""},
+		{"zero", []float64{0}, []string{"0.0"}, ""},
+		{"units", []float64{0, 2.5, 5}, []string{"0.0", "2.5", "5.0"}, ""},
+		{"small", []float64{0, 0.002, 0.004}, []string{"0.0", "2.0", "4.0"}, "×1e-3"},
+		{"negative", []float64{-4000, 0, 4000}, []string{"-4.0", "0.0", "4.0"}, "×1e3"},
+		{"offset", []float64{1000001, 1000002, 1000003}, []string{"1.0", "2.0", "3.0"}, "+1e+06"},
+		{"round up", []float64{0, 9.99}, []string{"0.0", "1.0"}, "×1e1"},
+		{"NaN", []float64{nan, 0, 2000}, []string{"NaN", "0.0", "2.0"}, "×1e3"},
+		{"only NaN", []float64{nan}, []string{"NaN"}, ""},
+	}
+	for _, tt := range tests {
+		labels, shared := SciFormatter(1).Format(tt.values)
+		if !reflect.DeepEqual(labels, tt.labels) || shared != tt.shared {
+			t.Errorf("%v: Format(%v) = %q %q, want %q %q",
+				tt.name, tt.values, labels, shared, tt.labels, tt.shared)
+		}
+	}
+}
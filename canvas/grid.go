@@ -0,0 +1,201 @@
+package canvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+)
+
+// LineStyle defines the dash pattern used to draw a line.
+type LineStyle byte
+
+// Constants used to define the LineStyle of a line.
+const (
+	Solid LineStyle = iota
+	Dashed
+	Dotted
+	DashDot
+)
+
+// pattern returns the length in pixels of each dash and gap of
+// the LineStyle for a line of width w.
+// A nil pattern draws a solid line.
+func (s LineStyle) pattern(w int) []int {
+	if w < 1 {
+		w = 1
+	}
+	switch s {
+	case Dashed:
+		return []int{6 * w, 4 * w}
+	case Dotted:
+		return []int{w, 2 * w}
+	case DashDot:
+		return []int{6 * w, 3 * w, w, 3 * w}
+	}
+	return nil
+}
+
+// GridStyle defines how to draw the grid lines of an Axis.
+// The Width of the lines is defined in points.
+type GridStyle struct {
+	Show  bool
+	Color color.Color
+	Width int
+	Style LineStyle
+}
+
+// TickDirection defines on which side of the Axes border a Tick is drawn.
+type TickDirection byte
+
+// Constants used to define the TickDirection of an Axis.
+const (
+	TickOut TickDirection = iota
+	TickIn
+	TickInOut
+)
+
+// extent returns the length in pixels of a Tick of length l drawn
+// outside and inside the Axes.
+func (d TickDirection) extent(l int) (out, in int) {
+	switch d {
+	case TickIn:
+		return 0, l
+	case TickInOut:
+		return l / 2, l - l/2
+	}
+	return l, 0
+}
+
+// Locator returns the location of the minor ticks between min and max
+// given the location of the major ticks.
+type Locator func(min, max float64, major []float64) []float64
+
+// AutoMinor divides each interval between major ticks into n parts.
+// The interval is taken from the two lowest distinct major ticks.
+func AutoMinor(n int) Locator {
+	return func(min, max float64, major []float64) []float64 {
+		if n < 2 {
+			return nil
+		}
+		// The major ticks may be set in any order or repeated.
+		sorted := append([]float64{}, major...)
+		sort.Float64s(sorted)
+		major = sorted[:0]
+		for i, v := range sorted {
+			if i == 0 || v != sorted[i-1] {
+				major = append(major, v)
+			}
+		}
+		if len(major) < 2 {
+			return nil
+		}
+		step := math.Abs(major[1]-major[0]) / float64(n)
+		if step == 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+			return nil
+		}
+		var ticks []float64
+		for v := major[0] - step; v >= min; v -= step {
+			ticks = append(ticks, v)
+		}
+		for i := range major {
+			for j := 1; j < n; j++ {
+				if v := major[i] + float64(j)*step; v <= max {
+					ticks = append(ticks, v)
+				}
+			}
+		}
+		return ticks
+	}
+}
+
+// MultipleLocator places ticks at every multiple of step that is not
+// a major tick.
+func MultipleLocator(step float64) Locator {
+	return func(min, max float64, major []float64) []float64 {
+		if step <= 0 {
+			return nil
+		}
+		var ticks []float64
+	next:
+		for i := math.Ceil(min / step); i*step <= max; i++ {
+			v := i * step
+			for _, m := range major {
+				if math.Abs(v-m) < step*1e-9 {
+					continue next
+				}
+			}
+			ticks = append(ticks, v)
+		}
+		return ticks
+	}
+}
+
+// styledLine draws a horizontal or vertical line from p0 to p1
+// with a width of w pixels following the dash pattern of style.
+func styledLine(dst draw.Image, p0, p1 image.Point, w int, style LineStyle, c color.Color) {
+	src := &image.Uniform{c}
+	if w < 1 {
+		w = 1
+	}
+	horizontal := p0.Y == p1.Y
+	r := image.Rect(p0.X, p0.Y, p1.X, p1.Y).Canon()
+	if horizontal {
+		r.Min.Y -= w / 2
+		r.Max.Y = r.Min.Y + w
+	} else {
+		r.Min.X -= w / 2
+		r.Max.X = r.Min.X + w
+	}
+
+	pattern := style.pattern(w)
+	if pattern == nil {
+		draw.Draw(dst, r, src, image.ZP, draw.Over)
+		return
+	}
+
+	start, end := r.Min.Y, r.Max.Y
+	if horizontal {
+		start, end = r.Min.X, r.Max.X
+	}
+	for i, p := 0, start; p < end; i = (i + 1) % len(pattern) {
+		q := p + pattern[i]
+		if q > end {
+			q = end
+		}
+		if i%2 == 0 {
+			dash := image.Rect(r.Min.X, p, r.Max.X, q)
+			if horizontal {
+				dash = image.Rect(p, r.Min.Y, q, r.Max.Y)
+			}
+			draw.Draw(dst, dash, src, image.ZP, draw.Over)
+		}
+		p = q
+	}
+}
+
+// renderGrid draws the major and minor grid lines of the Axis across
+// its parent Axes.
+func (a *Axis) renderGrid(dst draw.Image) {
+	lines := func(pos []float64, g GridStyle) {
+		if !g.Show {
+			return
+		}
+		w := a.Parent.Parent.pxi(g.Width)
+		for _, p := range pos {
+			var x0, y0, x1, y1 float64
+			switch a.Loc {
+			case BottomAxis, TopAxis:
+				x0, y0 = pixel(a, p, 0)
+				x1, y1 = pixel(a, p, 1)
+			case LeftAxis, RightAxis:
+				x0, y0 = pixel(a, 0, p)
+				x1, y1 = pixel(a, 1, p)
+			}
+			styledLine(dst, image.Pt(int(x0), int(y0)), image.Pt(int(x1), int(y1)), w, g.Style, g.Color)
+		}
+	}
+	lines(a.minor, a.MinorGrid)
+	lines(a.major, a.Grid)
+}
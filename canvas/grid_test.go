@@ -0,0 +1,112 @@
+package canvas
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAutoMinor(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		min, max float64
+		major    []float64
+		want     []float64
+	}{
+		{"halves", 2, 0, 3, []float64{1, 2, 3}, []float64{0.5, 0, 1.5, 2.5}},
+		{"quarters", 4, 1, 2, []float64{1, 2}, []float64{1.25, 1.5, 1.75}},
+		{"descending", 2, 0, 3, []float64{3, 2, 1}, []float64{0.5, 0, 1.5, 2.5}},
+		{"duplicated", 2, 0, 3, []float64{1, 1, 2, 2, 3}, []float64{0.5, 0, 1.5, 2.5}},
+		{"single", 2, 0, 3, []float64{1}, nil},
+		{"all equal", 2, 0, 3, []float64{2, 2, 2}, nil},
+		{"no major", 2, 0, 3, nil, nil},
+		{"one part", 1, 0, 3, []float64{1, 2, 3}, nil},
+		{"NaN", 2, 0, 3, []float64{1, math.NaN()}, nil},
+	}
+	for _, tt := range tests {
+		if got := AutoMinor(tt.n)(tt.min, tt.max, tt.major); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: AutoMinor(%v)(%v, %v, %v) = %v, want %v",
+				tt.name, tt.n, tt.min, tt.max, tt.major, got, tt.want)
+		}
+	}
+}
+
+func TestMultipleLocator(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     float64
+		min, max float64
+		major    []float64
+		want     []float64
+	}{
+		{"skip major", 0.5, 0, 2, []float64{0, 1, 2}, []float64{0.5, 1.5}},
+		{"descending", 0.5, 0, 2, []float64{2, 1, 0}, []float64{0.5, 1.5}},
+		{"duplicated", 0.5, 0, 2, []float64{1, 1, 1}, []float64{0, 0.5, 1.5, 2}},
+		{"negative range", 1, -2.5, 0.5, nil, []float64{-2, -1, 0}},
+		{"zero step", 0, 0, 2, nil, nil},
+		{"negative step", -1, 0, 2, nil, nil},
+	}
+	for _, tt := range tests {
+		if got := MultipleLocator(tt.step)(tt.min, tt.max, tt.major); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: MultipleLocator(%v)(%v, %v, %v) = %v, want %v",
+				tt.name, tt.step, tt.min, tt.max, tt.major, got, tt.want)
+		}
+	}
+}
+
+// TestSetMinorDescending sets the minor ticks of an Axis whose major
+// ticks are descending.
+func TestSetMinorDescending(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	ax.SetXLim(0, 4)
+	a := ax.Axis(BottomAxis)
+	a.SetTicks([]float64{3, 2, 1}, nil)
+
+	done := make(chan struct{})
+	go func() {
+		a.SetMinor(AutoMinor(2))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("SetMinor did not finish")
+	}
+	if n := len(a.minor); n != 5 {
+		t.Errorf("%v minor ticks, want 5", n)
+	}
+}
+
+func TestNewTick(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	tests := []struct {
+		loc  Alignment
+		want [2]float64
+	}{
+		{BottomAxis, [2]float64{0.25, 0.5}},
+		{TopAxis, [2]float64{0.25, 0.5}},
+		{LeftAxis, [2]float64{0.5, 0.75}},
+		{RightAxis, [2]float64{0.5, 0.75}},
+	}
+	for _, tt := range tests {
+		a := ax.Axis(tt.loc)
+		tick, err := NewTick(a, 0.25, 0.75, 0.2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tick.Origin != tt.want || tick.W != 3 || tick.Parent != a {
+			t.Errorf("Tick on Axis %v at %v with width %v, want %v with width 3",
+				tt.loc, tick.Origin, tick.W, tt.want)
+		}
+	}
+}
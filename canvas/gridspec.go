@@ -0,0 +1,240 @@
+package canvas
+
+import (
+	"fmt"
+)
+
+// GridSpec divides an area of a Figure into a grid of cells where Axes
+// can be placed.
+// An Axes, or a nested GridSpec, can cover a single cell or span several
+// rows and columns.
+// Rows are counted from the top and columns from the left.
+type GridSpec struct {
+	Parent     *Figure
+	Rows, Cols int
+	// WidthRatios and HeightRatios hold the relative size of each column
+	// and row. Nil ratios make all the cells the same size.
+	WidthRatios, HeightRatios []float64
+	// WSpace and HSpace are the space between columns and rows relative
+	// to the average width and height of the cells.
+	WSpace, HSpace float64
+
+	sharex, sharey bool
+	region         [4]float64
+	items          []*gridItem
+}
+
+// gridItem is an Axes or a nested GridSpec covering the cells of
+// a GridSpec from row0 to row1 and col0 to col1, not included.
+type gridItem struct {
+	span [4]int
+	ax   *Axes
+	spec *GridSpec
+}
+
+// NewGridSpec attaches a new GridSpec of rows and columns into the Figure.
+func (f *Figure) NewGridSpec(rows, cols int, opts ...GridOption) (*GridSpec, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.addGridSpec(rows, cols, opts...)
+}
+
+// addGridSpec attaches the GridSpec of NewGridSpec.
+func (f *Figure) addGridSpec(rows, cols int, opts ...GridOption) (*GridSpec, error) {
+	gs, err := newGridSpec(f, rows, cols, [4]float64{0.12, 0.08, 0.76, 0.84}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	f.specs = append(f.specs, gs)
+	return gs, nil
+}
+
+// newGridSpec creates a new GridSpec covering the region {x, y, w, h}
+// of the Figure.
+func newGridSpec(f *Figure, rows, cols int, region [4]float64, opts ...GridOption) (*GridSpec, error) {
+	if rows < 1 || cols < 1 {
+		return nil, fmt.Errorf("GridSpec of %vx%v cells not valid", rows, cols)
+	}
+	gs := &GridSpec{
+		Parent: f,
+		Rows:   rows,
+		Cols:   cols,
+		WSpace: 0.2,
+		HSpace: 0.2,
+		region: region,
+	}
+	for _, opt := range opts {
+		opt(gs)
+	}
+	return gs, nil
+}
+
+// SetWidthRatios sets the relative width of each column and relocates
+// the Axes of the GridSpec.
+func (gs *GridSpec) SetWidthRatios(ratios ...float64) {
+	gs.Parent.Lock()
+	defer gs.Parent.Unlock()
+	gs.WidthRatios = ratios
+	gs.layout()
+}
+
+// SetHeightRatios sets the relative height of each row and relocates
+// the Axes of the GridSpec.
+func (gs *GridSpec) SetHeightRatios(ratios ...float64) {
+	gs.Parent.Lock()
+	defer gs.Parent.Unlock()
+	gs.HeightRatios = ratios
+	gs.layout()
+}
+
+// SetSpace sets the space between columns and rows and relocates
+// the Axes of the GridSpec.
+func (gs *GridSpec) SetSpace(wspace, hspace float64) {
+	gs.Parent.Lock()
+	defer gs.Parent.Unlock()
+	gs.WSpace, gs.HSpace = wspace, hspace
+	gs.layout()
+}
+
+// checkSpan returns an error if the span is outside of the GridSpec.
+func (gs *GridSpec) checkSpan(row0, row1, col0, col1 int) error {
+	if row0 < 0 || col0 < 0 || row1 > gs.Rows || col1 > gs.Cols || row0 >= row1 || col0 >= col1 {
+		return fmt.Errorf(
+			"Span rows [%v, %v) and columns [%v, %v) not valid in a GridSpec of %vx%v cells",
+			row0, row1, col0, col1, gs.Rows, gs.Cols)
+	}
+	return nil
+}
+
+// Axes attaches a new Axes covering the cells from row0 to row1 and
+// from col0 to col1, not included.
+// For example, gs.Axes(0, 1, 0, 2) spans the first two columns of
+// the first row.
+func (gs *GridSpec) Axes(row0, row1, col0, col1 int) (*Axes, error) {
+	gs.Parent.Lock()
+	defer gs.Parent.Unlock()
+	return gs.axes(row0, row1, col0, col1)
+}
+
+// axes attaches the Axes of Axes.
+func (gs *GridSpec) axes(row0, row1, col0, col1 int) (*Axes, error) {
+	if err := gs.checkSpan(row0, row1, col0, col1); err != nil {
+		return nil, err
+	}
+
+	box := gs.box([4]int{row0, row1, col0, col1})
+	ax, err := newAxes(gs.Parent, box[0], box[1], box[2], box[3])
+	if err != nil {
+		return nil, err
+	}
+	ax.spec = gs
+	ax.span = [4]int{row0, row1, col0, col1}
+	gs.items = append(gs.items, &gridItem{span: ax.span, ax: ax})
+
+	for _, it := range gs.items {
+		if it.ax == nil || it.ax == ax {
+			continue
+		}
+		if gs.sharex {
+			ax.shareX(it.ax)
+		}
+		if gs.sharey {
+			ax.shareY(it.ax)
+		}
+		break
+	}
+
+	return ax, nil
+}
+
+// SubGridSpec attaches a new GridSpec of rows and columns nested inside
+// the cells from row0 to row1 and from col0 to col1, not included.
+func (gs *GridSpec) SubGridSpec(row0, row1, col0, col1, rows, cols int, opts ...GridOption) (*GridSpec, error) {
+	gs.Parent.Lock()
+	defer gs.Parent.Unlock()
+	if err := gs.checkSpan(row0, row1, col0, col1); err != nil {
+		return nil, err
+	}
+
+	span := [4]int{row0, row1, col0, col1}
+	sub, err := newGridSpec(gs.Parent, rows, cols, gs.box(span), opts...)
+	if err != nil {
+		return nil, err
+	}
+	gs.items = append(gs.items, &gridItem{span: span, spec: sub})
+
+	return sub, nil
+}
+
+// weights returns the ratios normalized to add up to 1.
+// Missing or invalid ratios give the same weight to each of the n cells.
+func weights(ratios []float64, n int) []float64 {
+	w := make([]float64, n)
+	var sum float64
+	if len(ratios) == n {
+		for _, r := range ratios {
+			sum += r
+		}
+	}
+	for i := range w {
+		if sum <= 0 {
+			w[i] = 1 / float64(n)
+			continue
+		}
+		w[i] = ratios[i] / sum
+	}
+	return w
+}
+
+// cells returns the start and size of each of the n cells dividing
+// a length with a space between cells relative to their average size.
+func cells(start, length, space float64, ratios []float64, n int) (pos, size []float64) {
+	avg := length / (float64(n) + float64(n-1)*space)
+	w := weights(ratios, n)
+	pos = make([]float64, n)
+	size = make([]float64, n)
+	for i := range pos {
+		size[i] = float64(n) * avg * w[i]
+		pos[i] = start
+		start += size[i] + space*avg
+	}
+	return pos, size
+}
+
+// box returns the region {x, y, w, h} of the Figure covered by span.
+func (gs *GridSpec) box(span [4]int) [4]float64 {
+	r := gs.region
+	x, w := cells(r[0], r[2], gs.WSpace, gs.WidthRatios, gs.Cols)
+	// Rows are counted from the top, so they are laid out top to bottom.
+	y, h := cells(0, r[3], gs.HSpace, gs.HeightRatios, gs.Rows)
+
+	x0 := x[span[2]]
+	x1 := x[span[3]-1] + w[span[3]-1]
+	top := r[1] + r[3] - y[span[0]]
+	bottom := r[1] + r[3] - (y[span[1]-1] + h[span[1]-1])
+
+	return [4]float64{x0, bottom, x1 - x0, top - bottom}
+}
+
+// place moves the Axes to the region {x, y, w, h} of the Figure,
+// keeping the part of the region it covers.
+func (ax *Axes) place(box [4]float64) {
+	ax.setPosition(
+		[2]float64{box[0] + ax.sub[0]*box[2], box[1] + ax.sub[1]*box[3]},
+		[2]float64{ax.sub[2] * box[2], ax.sub[3] * box[3]},
+	)
+}
+
+// layout relocates every Axes and nested GridSpec of the GridSpec.
+func (gs *GridSpec) layout() {
+	for _, it := range gs.items {
+		box := gs.box(it.span)
+		if it.ax != nil {
+			it.ax.place(box)
+		}
+		if it.spec != nil {
+			it.spec.region = box
+			it.spec.layout()
+		}
+	}
+}
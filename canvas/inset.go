@@ -0,0 +1,115 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/cgxeiji/plt/bag/pen"
+)
+
+// Inset attaches a new Axes inside ax located by bounds {x, y, w, h}
+// in the coordinates of ax, (0, 0) being its lower left corner and
+// (1, 1) its upper right corner.
+// The inset follows ax when it is moved or resized and is drawn on
+// top of it.
+func (ax *Axes) Inset(bounds [4]float64) (*Axes, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if bounds[2] <= 0 || bounds[3] <= 0 {
+		return nil, fmt.Errorf("Inset size not valid (%v x %v)", bounds[2], bounds[3])
+	}
+
+	in, err := newAxes(ax.Parent, bounds[0], bounds[1], bounds[2], bounds[3])
+	if err != nil {
+		return nil, err
+	}
+	// Chain the placement of the inset to the placement of ax.
+	in.t = ax.tc
+	in.tc.setParent(ax.tc)
+	in.update()
+
+	return in, nil
+}
+
+// ZoomIndicator represents the rectangle of the data region shown by
+// an inset Axes, drawn in its parent Axes, with the lines connecting
+// it to the inset.
+type ZoomIndicator struct {
+	primitive
+	Parent *Axes
+	Inset  *Axes
+	// W is the width of the lines in points.
+	W int
+}
+
+// IndicateZoom draws the data limits of inset as a rectangle in ax,
+// connected to the corners of inset.
+// The rectangle follows any change of the limits of inset.
+func (ax *Axes) IndicateZoom(inset *Axes) (*ZoomIndicator, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if inset == nil || inset == ax {
+		return nil, fmt.Errorf("Inset not valid")
+	}
+
+	var z ZoomIndicator
+	z.Parent = ax
+	z.Inset = inset
+	z.W = 1
+	z.t = ax.dataT
+	z.StrokeColor = ax.Parent.theme.EdgeColor
+
+	ax.children = append(ax.children, &z)
+	return &z, nil
+}
+
+// Bounds returns the pixels covered by the data limits of the inset
+// in the parent Axes.
+func (z *ZoomIndicator) Bounds() image.Rectangle {
+	x0, y0 := pixel(z, z.Inset.XLim[0], z.Inset.YLim[0])
+	x1, y1 := pixel(z, z.Inset.XLim[1], z.Inset.YLim[1])
+	return image.Rect(int(x0), int(y0), int(x1), int(y1)).Canon()
+}
+
+// connectors returns the pairs of points joining the rectangle r to
+// the closest side of the inset in.
+func connectors(r, in image.Rectangle) [2][2]image.Point {
+	switch {
+	case in.Min.X >= r.Max.X:
+		return [2][2]image.Point{
+			{image.Pt(r.Max.X, r.Min.Y), image.Pt(in.Min.X, in.Min.Y)},
+			{image.Pt(r.Max.X, r.Max.Y), image.Pt(in.Min.X, in.Max.Y)},
+		}
+	case in.Max.X <= r.Min.X:
+		return [2][2]image.Point{
+			{image.Pt(r.Min.X, r.Min.Y), image.Pt(in.Max.X, in.Min.Y)},
+			{image.Pt(r.Min.X, r.Max.Y), image.Pt(in.Max.X, in.Max.Y)},
+		}
+	case in.Max.Y <= r.Min.Y:
+		return [2][2]image.Point{
+			{image.Pt(r.Min.X, r.Min.Y), image.Pt(in.Min.X, in.Max.Y)},
+			{image.Pt(r.Max.X, r.Min.Y), image.Pt(in.Max.X, in.Max.Y)},
+		}
+	}
+	return [2][2]image.Point{
+		{image.Pt(r.Min.X, r.Max.Y), image.Pt(in.Min.X, in.Min.Y)},
+		{image.Pt(r.Max.X, r.Max.Y), image.Pt(in.Max.X, in.Min.Y)},
+	}
+}
+
+// Render draws the rectangle of the ZoomIndicator and, unless both
+// overlap, its connector lines.
+func (z *ZoomIndicator) Render(dst draw.Image) {
+	r := z.Bounds()
+	w := z.Parent.Parent.pxi(z.W)
+	border(dst, r, w, &image.Uniform{z.StrokeColor}, image.ZP, draw.Over)
+
+	in := z.Inset.Bounds()
+	if r.Overlaps(in) {
+		return
+	}
+	for _, c := range connectors(r, in) {
+		pen.Line(dst, c[0], c[1], w, z.StrokeColor)
+	}
+}
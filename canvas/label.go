@@ -2,26 +2,121 @@ package canvas
 
 import (
 	"image"
+	"image/color"
 	"image/draw"
 	"io/ioutil"
 	"log"
+	"math"
+	"sync"
 
 	"github.com/golang/freetype/truetype"
-	"golang.org/x/image/colornames"
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
-	"gonum.org/v1/gonum/mat"
 )
 
+// Label represents the text of a tick with Axis as its parent.
 type Label struct {
 	primitive
 	Parent *Axis
 	Text   string
+	// Rotation is the angle of the text in degrees, counterclockwise.
+	Rotation float64
+
+	hidden bool
+	lines  []string
 }
 
+// Render draws the Label with the Typer of its parent.
+// Rotated labels are anchored by the end of the text closest to the Axis.
 func (l *Label) Render(dst draw.Image) {
-	location := l.Bounds().Min
-	l.Parent.Typer.Render(dst, location.X, location.Y, l.Text)
+	if l.hidden {
+		return
+	}
+	t := l.Parent.Typer
+	t.Drawer.Src = &image.Uniform{l.Color()}
+
+	if l.Rotation != 0 {
+		p := l.anchorPoint()
+		t.XAlign, t.YAlign = l.anchor()
+		t.RenderRotated(dst, p.X, p.Y, l.Text, l.Rotation)
+		return
+	}
+
+	lines, location := l.textLines()
+	t.XAlign = l.XAlign
+	for i, line := range lines {
+		t.Render(dst, location.X, location.Y+i*t.lineHeight(), line)
+	}
+}
+
+// Extent returns the pixels covered by the text of the Label.
+// It needs the Typer of its parent, see Axis.prepare.
+func (l *Label) Extent() image.Rectangle {
+	t := l.Parent.Typer
+	if l.hidden || t == nil {
+		return image.Rectangle{}
+	}
+
+	if l.Rotation != 0 {
+		p := l.anchorPoint()
+		t.XAlign, t.YAlign = l.anchor()
+		return t.rotatedBounds(p.X, p.Y, l.Text, l.Rotation)
+	}
+
+	lines, location := l.textLines()
+	var w int
+	for _, line := range lines {
+		w = max(w, t.Drawer.MeasureString(line).Ceil())
+	}
+	x := location.X
+	switch l.XAlign {
+	case CenterAlign:
+		x -= w / 2
+	case RightAlign:
+		x -= w
+	}
+	h := (len(lines)-1)*t.lineHeight() + (t.Height + t.Drawer.Face.Metrics().Descent).Ceil()
+	return image.Rect(x, location.Y, x+w, location.Y+h)
+}
+
+// anchorPoint returns the point in pixels where a rotated Label is anchored.
+func (l *Label) anchorPoint() image.Point {
+	x, y := pixel(l, l.Origin[0], l.Origin[1])
+	return image.Pt(int(x), int(y)).Add(l.Parent.labelOffset())
+}
+
+// textLines returns the lines of text of the Label and the top point
+// in pixels of the first line.
+func (l *Label) textLines() ([]string, image.Point) {
+	lines := l.lines
+	if lines == nil {
+		lines = []string{l.Text}
+	}
+	location := l.Bounds().Min.Add(l.Parent.labelOffset())
+	if l.YAlign == BottomAlign {
+		location.Y -= (len(lines) - 1) * l.Parent.Typer.lineHeight()
+	}
+	return lines, location
+}
+
+// anchor returns the alignment of the text box that stays fixed
+// when the Label is rotated.
+func (l *Label) anchor() (x, y Alignment) {
+	switch l.Parent.Loc {
+	case BottomAxis:
+		if l.Rotation < 0 {
+			return LeftAlign, CenterAlign
+		}
+		return RightAlign, CenterAlign
+	case TopAxis:
+		if l.Rotation < 0 {
+			return RightAlign, CenterAlign
+		}
+		return LeftAlign, CenterAlign
+	case LeftAxis:
+		return RightAlign, CenterAlign
+	}
+	return LeftAlign, CenterAlign
 }
 
 func newLabel(parent *Axis, x, y, h float64, text string) (*Label, error) {
@@ -31,16 +126,76 @@ func newLabel(parent *Axis, x, y, h float64, text string) (*Label, error) {
 	l.Size = [2]float64{0, h}
 	l.XAlign = CenterAlign
 	l.YAlign = CenterAlign
-	Tc := mat.DenseCopyOf(iM)
-	l.T = append(l.T, parent.T...)
-	l.T = append(l.T, Tc)
-	l.FillColor = colornames.Black
+	l.t = parent.tc
+	l.FillColor = parent.Parent.Parent.theme.TextColor
 	l.Text = text
 
 	parent.children = append(parent.children, &l)
 	return &l, nil
 }
 
+// Text represents a string located in data coordinates with Axes as its parent.
+type Text struct {
+	primitive
+	Parent *Axes
+	X, Y   float64
+	Text   string
+	// H is the height of the text relative to the shortest side of the Axes.
+	H float64
+}
+
+// newText creates a new Text centered at (x, y) in data coordinates
+// linked to an Axes.
+func newText(parent *Axes, x, y float64, text string) (*Text, error) {
+	var t Text
+	t.Parent = parent
+	t.X = x
+	t.Y = y
+	t.Text = text
+	t.H = 0.06
+	t.XAlign = CenterAlign
+	t.YAlign = CenterAlign
+	t.t = parent.dataT
+	t.FillColor = parent.Parent.theme.TextColor
+
+	parent.children = append(parent.children, &t)
+	return &t, nil
+}
+
+// Render draws the Text into a draw.Image interface.
+// The size of the font is calculated on render from the size of the Axes.
+func (t *Text) Render(dst draw.Image) {
+	typer, x, y := t.typer()
+	typer.Render(dst, x, y, t.Text)
+}
+
+// Extent returns the pixels covered by the Text.
+func (t *Text) Extent() image.Rectangle {
+	typer, x, y := t.typer()
+	w, h, ax, _ := typer.box(t.Text)
+	x -= int(ax)
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// typer returns the font used by the Text and the point in pixels where
+// the text starts, according to XAlign, and its top.
+func (t *Text) typer() (*fontType, int, int) {
+	b := t.Parent.Bounds()
+	height := int(float64(min(b.Dx(), b.Dy())) * t.H)
+	typer := t.Parent.Parent.newFont(height)
+	typer.Drawer.Src = &image.Uniform{t.Color()}
+	typer.XAlign = t.XAlign
+
+	x, y := pixel(t, t.X, t.Y)
+	switch t.YAlign {
+	case CenterAlign:
+		y -= float64(height) / 2
+	case BottomAlign:
+		y -= float64(height)
+	}
+	return typer, int(x), int(y)
+}
+
 type fontType struct {
 	Drawer         *font.Drawer
 	Height         fixed.Int26_6
@@ -71,27 +226,138 @@ func (f *fontType) Render(dst draw.Image, X, Y int, text string) {
 	d.DrawString(text)
 }
 
-func newFont(size int) (*fontType, error) {
-	fg := image.Black
+// lineHeight returns the distance in pixels between two lines of text.
+func (f *fontType) lineHeight() int {
+	return (f.Height * 6 / 5).Ceil()
+}
+
+// box returns the size in pixels of the text box of text and the point of
+// the box located by XAlign and YAlign.
+func (f *fontType) box(text string) (w, h int, ax, ay float64) {
+	d := f.Drawer
+	w = d.MeasureString(text).Ceil()
+	h = (f.Height + d.Face.Metrics().Descent).Ceil()
+
+	switch f.XAlign {
+	case CenterAlign:
+		ax = float64(w) / 2
+	case RightAlign:
+		ax = float64(w)
+	}
+	switch f.YAlign {
+	case CenterAlign:
+		ay = float64(h) / 2
+	case BottomAlign:
+		ay = float64(h)
+	}
+	return w, h, ax, ay
+}
+
+// rotatedBounds returns the pixels covered by text rotated deg degrees
+// counterclockwise around the point (X, Y).
+func (f *fontType) rotatedBounds(X, Y int, text string, deg float64) image.Rectangle {
+	w, h, ax, ay := f.box(text)
+	sin, cos := math.Sincos(deg * math.Pi / 180)
+
+	var r image.Rectangle
+	for i, c := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		u, v := c[0]*float64(w)-ax, c[1]*float64(h)-ay
+		x, y := float64(X)+u*cos+v*sin, float64(Y)-u*sin+v*cos
+		p := image.Rect(int(math.Floor(x)), int(math.Floor(y)), int(math.Ceil(x))+1, int(math.Ceil(y))+1)
+		if i == 0 {
+			r = p
+			continue
+		}
+		r = r.Union(p)
+	}
+	return r
+}
+
+// RenderRotated draws text rotated deg degrees counterclockwise around
+// the point (X, Y).
+// The point is located on the text box according to XAlign and YAlign.
+func (f *fontType) RenderRotated(dst draw.Image, X, Y int, text string, deg float64) {
+	d := f.Drawer
+	w, h, ax, ay := f.box(text)
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	// Draw the text horizontally into a mask to rotate it afterwards.
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+	(&font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: d.Face,
+		Dot:  fixed.Point26_6{Y: f.Height},
+	}).DrawString(text)
+
+	sin, cos := math.Sincos(deg * math.Pi / 180)
+	r := f.rotatedBounds(X, Y, text, deg)
+
+	rotated := image.NewAlpha(r)
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			dx, dy := float64(px)+0.5-float64(X), float64(py)+0.5-float64(Y)
+			u := dx*cos - dy*sin + ax
+			v := dx*sin + dy*cos + ay
+			rotated.SetAlpha(px, py, color.Alpha{bilinear(mask, u-0.5, v-0.5)})
+		}
+	}
+
+	draw.DrawMask(dst, r, d.Src, image.ZP, rotated, r.Min, draw.Over)
+}
+
+// bilinear returns the alpha of the mask at the point (x, y)
+// interpolated from its four closest pixels.
+func bilinear(mask *image.Alpha, x, y float64) uint8 {
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	fx, fy := x-float64(x0), y-float64(y0)
+	at := func(x, y int) float64 {
+		if !(image.Point{x, y}.In(mask.Rect)) {
+			return 0
+		}
+		return float64(mask.AlphaAt(x, y).A)
+	}
+	top := at(x0, y0)*(1-fx) + at(x0+1, y0)*fx
+	bottom := at(x0, y0+1)*(1-fx) + at(x0+1, y0+1)*fx
+	return uint8(top*(1-fy) + bottom*fy + 0.5)
+}
+
+// newFont returns a font of size points rendered at dpi pixels per inch.
+func newFont(ttf *truetype.Font, size, dpi float64, c color.Color) (*fontType, error) {
+	fg := &image.Uniform{c}
 
 	d := &font.Drawer{
 		Src: fg,
-		Face: truetype.NewFace(defaultFont, &truetype.Options{
-			Size:    float64(size),
-			DPI:     300,
+		Face: truetype.NewFace(ttf, &truetype.Options{
+			Size:    size,
+			DPI:     dpi,
 			Hinting: font.HintingNone,
 		}),
 	}
 
 	t := &fontType{
 		Drawer: d,
-		Height: fixed.I(size * 300 / 72),
+		Height: fixed.I(int(math.Round(size * dpi / 72))),
 	}
 
 	return t, nil
 }
 
-var defaultFont = parseFont("luxisr.ttf")
+var (
+	defaultOnce sync.Once
+	defaultTTF  *truetype.Font
+)
+
+// defaultFont returns the typeface of DefaultTheme, read from
+// luxisr.ttf in the working directory the first time it is needed.
+func defaultFont() *truetype.Font {
+	defaultOnce.Do(func() {
+		defaultTTF = parseFont("luxisr.ttf")
+	})
+	return defaultTTF
+}
 
 func parseFont(file string) *truetype.Font {
 	bytes, err := ioutil.ReadFile(file)
@@ -0,0 +1,53 @@
+package canvas
+
+import "testing"
+
+func TestLabelAnchor(t *testing.T) {
+	tests := []struct {
+		loc      Alignment
+		rotation float64
+		x, y     Alignment
+	}{
+		{BottomAxis, 45, RightAlign, CenterAlign},
+		{BottomAxis, 90, RightAlign, CenterAlign},
+		{BottomAxis, -45, LeftAlign, CenterAlign},
+		{TopAxis, 45, LeftAlign, CenterAlign},
+		{TopAxis, 90, LeftAlign, CenterAlign},
+		{TopAxis, -45, RightAlign, CenterAlign},
+		{LeftAxis, 45, RightAlign, CenterAlign},
+		{LeftAxis, -45, RightAlign, CenterAlign},
+		{RightAxis, 45, LeftAlign, CenterAlign},
+		{RightAxis, -45, LeftAlign, CenterAlign},
+	}
+	for _, tt := range tests {
+		fig, err := NewFigure(600, 400)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := fig.NewAxes().Axis(tt.loc)
+		a.Rotation = tt.rotation
+		a.SetTicks([]float64{0.5}, []string{"anchored"})
+		a.prepare()
+		l := a.tickLabels[0]
+
+		if x, y := l.anchor(); x != tt.x || y != tt.y {
+			t.Errorf("Axis %v rotated %v: anchored at %v %v, want %v %v",
+				tt.loc, tt.rotation, x, y, tt.x, tt.y)
+		}
+
+		// The text is anchored by its end closest to the Axis, so it
+		// stays on the outer side of the anchor point.
+		p, e := l.anchorPoint(), l.Extent()
+		c := e.Min.Add(e.Max).Div(2)
+		out := map[Alignment]bool{
+			BottomAxis: c.Y > p.Y,
+			TopAxis:    c.Y < p.Y,
+			LeftAxis:   c.X < p.X,
+			RightAxis:  c.X > p.X,
+		}
+		if !out[tt.loc] || !p.In(e.Inset(-2)) {
+			t.Errorf("Axis %v rotated %v: text %v not anchored at %v",
+				tt.loc, tt.rotation, e, p)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package canvas
+
+import (
+	"image"
+)
+
+// extenter is implemented by the Containers that draw beyond the Bounds
+// of their parent, such as text.
+type extenter interface {
+	Extent() image.Rectangle
+}
+
+// textExtent returns the pixels covered by the Axes, everything drawn
+// by its children and its Legend.
+// Each Axis is prepared first, so the labels are measured with the font
+// they would be rendered with.
+func textExtent(ax *Axes) image.Rectangle {
+	r := ax.Bounds()
+
+	var walk func(c Container)
+	walk = func(c Container) {
+		if a, ok := c.(*Axis); ok {
+			a.prepare()
+		}
+		if e, ok := c.(extenter); ok {
+			r = r.Union(e.Extent())
+		}
+		for _, child := range c.Children() {
+			walk(child)
+		}
+	}
+	for _, child := range ax.Children() {
+		walk(child)
+	}
+	// The Legend is a child of the Figure, drawn over any Axes.
+	if ax.legend != nil {
+		r = r.Union(ax.legend.Bounds())
+	}
+
+	return r
+}
+
+// margins holds the space in pixels taken by the text around
+// the cells of a GridSpec.
+type margins struct {
+	left, right, top, bottom []int
+}
+
+// measure returns the largest margins of each row and column of
+// the GridSpec.
+// The text of an Axes spanning several cells is counted in the first
+// or last row and column of its span.
+func (gs *GridSpec) measure() margins {
+	m := margins{
+		left:   make([]int, gs.Cols),
+		right:  make([]int, gs.Cols),
+		top:    make([]int, gs.Rows),
+		bottom: make([]int, gs.Rows),
+	}
+
+	bounds := map[[4]int]image.Rectangle{}
+	extents := map[[4]int]image.Rectangle{}
+	for _, it := range gs.items {
+		if it.ax == nil {
+			continue
+		}
+		bounds[it.span] = bounds[it.span].Union(it.ax.Bounds())
+		extents[it.span] = extents[it.span].Union(textExtent(it.ax))
+		for _, t := range it.ax.twins {
+			extents[it.span] = extents[it.span].Union(textExtent(t))
+		}
+	}
+
+	for s, b := range bounds {
+		e := extents[s]
+		m.left[s[2]] = max(m.left[s[2]], b.Min.X-e.Min.X)
+		m.right[s[3]-1] = max(m.right[s[3]-1], e.Max.X-b.Max.X)
+		m.top[s[0]] = max(m.top[s[0]], b.Min.Y-e.Min.Y)
+		m.bottom[s[1]-1] = max(m.bottom[s[1]-1], e.Max.Y-b.Max.Y)
+	}
+
+	return m
+}
+
+// TightLayout moves and resizes the Axes of every GridSpec so all
+// their text, such as tick labels, fits inside the Figure without
+// overlapping, leaving pad points around each cell.
+// Nested GridSpecs are laid out inside the cells they cover.
+//
+// The font of the labels depends on the size of the Axes, so the layout
+// is measured a few times until it settles.
+// The layout runs again whenever the Figure is resized.
+func (f *Figure) TightLayout(pad int) {
+	f.Lock()
+	defer f.Unlock()
+	f.tightLayout(pad)
+}
+
+func (f *Figure) tightLayout(pad int) {
+	f.tight, f.pad = true, pad
+	pad = f.pxi(pad)
+	for i := 0; i < 3; i++ {
+		for _, gs := range f.specs {
+			gs.tightLayout([4]float64{0, 0, f.Size[0], f.Size[1]}, pad)
+		}
+	}
+}
+
+// tightLayout lays out the GridSpec inside the region {x, y, w, h}
+// in pixels, measured from the top left corner of the Figure.
+func (gs *GridSpec) tightLayout(region [4]float64, pad int) {
+	m := gs.measure()
+	W, H := gs.Parent.Size[0], gs.Parent.Size[1]
+
+	// cells returns the start and size in pixels of each cell along
+	// a side of the region, given the margins before and after them.
+	cells := func(start, length float64, before, after []int, ratios []float64) ([]float64, []float64, bool) {
+		n := len(before)
+		avail := length - float64((n+1)*pad)
+		for i := range before {
+			avail -= float64(before[i] + after[i])
+		}
+		if avail < float64(n) {
+			return nil, nil, false
+		}
+
+		pos := make([]float64, n)
+		size := make([]float64, n)
+		next := start + float64(pad)
+		for i, w := range weights(ratios, n) {
+			pos[i] = next + float64(before[i])
+			size[i] = avail * w
+			next = pos[i] + size[i] + float64(after[i]+pad)
+		}
+		return pos, size, true
+	}
+	x, w, okX := cells(region[0], region[2], m.left, m.right, gs.WidthRatios)
+	y, h, okY := cells(region[1], region[3], m.top, m.bottom, gs.HeightRatios)
+	if !okX || !okY {
+		return
+	}
+
+	for _, it := range gs.items {
+		s := it.span
+		x0, x1 := x[s[2]], x[s[3]-1]+w[s[3]-1]
+		y0, y1 := y[s[0]], y[s[1]-1]+h[s[1]-1]
+		box := [4]float64{x0 / W, 1 - y1/H, (x1 - x0) / W, (y1 - y0) / H}
+
+		if it.ax != nil {
+			it.ax.place(box)
+		}
+		if it.spec != nil {
+			it.spec.region = box
+			it.spec.tightLayout([4]float64{x0, y0, x1 - x0, y1 - y0}, pad)
+		}
+	}
+}
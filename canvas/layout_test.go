@@ -0,0 +1,29 @@
+package canvas
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTightLayoutLegend(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	if err := ax.LinePlot([]float64{0, 1, 2}, []float64{1, 3, 2}, LegendLabel("a long legend label")); err != nil {
+		t.Fatal(err)
+	}
+	l := ax.Legend()
+	l.Origin = [2]float64{1.02, 1}
+	l.XAlign = LeftAlign
+	fig.TightLayout(4)
+
+	b, lb := ax.Bounds(), l.Bounds()
+	if !lb.In(image.Rect(0, 0, 400, 300)) {
+		t.Errorf("Legend at %v outside of the Figure", lb)
+	}
+	if lb.Min.X < b.Max.X {
+		t.Errorf("Legend at %v overlaps the Axes at %v", lb, b)
+	}
+}
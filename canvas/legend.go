@@ -0,0 +1,165 @@
+package canvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/cgxeiji/plt/bag/pen"
+)
+
+// legendKind defines the symbol drawn next to a label of a Legend.
+type legendKind byte
+
+const (
+	legendLine legendKind = iota
+	legendMarker
+	legendPatch
+)
+
+// legendEntry holds the label and symbol of a plot shown in a Legend.
+type legendEntry struct {
+	label string
+	color color.Color
+	kind  legendKind
+}
+
+// addEntry records the plot for the Legend if the option LegendLabel is set.
+func (ax *Axes) addEntry(cfg *plotConfig, c color.Color, kind legendKind) {
+	if cfg.label == "" {
+		return
+	}
+	ax.entries = append(ax.entries, legendEntry{cfg.label, c, kind})
+}
+
+// Legend represents the box listing the labeled plots of an Axes and
+// of its twins.
+//
+// The Legend is located at Origin in the coordinates of the Axes,
+// (1, 1) being the upper right corner, and aligned to it by XAlign
+// and YAlign.
+type Legend struct {
+	primitive
+	Parent *Axes
+	// H is the height of the text relative to the shortest side of the Axes.
+	H float64
+}
+
+// Legend shows the plots of the Axes, and of its twins, labeled with
+// the option LegendLabel.
+// By default, the Legend is drawn at the upper right corner of the Axes.
+func (ax *Axes) Legend() *Legend {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	host := ax.root()
+	if host.legend != nil {
+		return host.legend
+	}
+
+	var l Legend
+	l.Parent = host
+	l.Origin = [2]float64{1, 1}
+	l.H = 0.04
+	l.XAlign = RightAlign
+	l.YAlign = TopAlign
+	l.t = host.tc
+	l.FillColor = host.Parent.theme.LegendColor
+	l.StrokeColor = host.Parent.theme.LegendEdge
+
+	host.legend = &l
+	host.Parent.children = append(host.Parent.children, &l)
+	return &l
+}
+
+// entries returns the labeled plots of the Axes and its twins.
+func (l *Legend) entries() []legendEntry {
+	host := l.Parent
+	entries := append([]legendEntry{}, host.entries...)
+	for _, t := range host.twins {
+		entries = append(entries, t.entries...)
+	}
+	return entries
+}
+
+// layout returns the font of the Legend and the pixels covered by it.
+func (l *Legend) layout(entries []legendEntry) (*fontType, image.Rectangle) {
+	b := l.Parent.Bounds()
+	height := int(float64(min(b.Dx(), b.Dy())) * l.H)
+	typer := l.Parent.Parent.newFont(height)
+	typer.XAlign = LeftAlign
+
+	lh := typer.lineHeight()
+	pad := lh / 2
+	var textW int
+	for _, e := range entries {
+		textW = max(textW, typer.Drawer.MeasureString(e.label).Ceil())
+	}
+	w := 4*pad + 2*lh + textW
+	h := 2*pad + len(entries)*lh
+
+	px, py := pixel(l, l.Origin[0], l.Origin[1])
+	x, y := int(px), int(py)
+	switch l.XAlign {
+	case LeftAlign:
+		x += pad
+	case CenterAlign:
+		x -= w / 2
+	case RightAlign:
+		x -= w + pad
+	}
+	switch l.YAlign {
+	case TopAlign:
+		y += pad
+	case CenterAlign:
+		y -= h / 2
+	case BottomAlign:
+		y -= h + pad
+	}
+	return typer, image.Rect(x, y, x+w, y+h)
+}
+
+// Bounds returns the pixels covered by the Legend.
+func (l *Legend) Bounds() image.Rectangle {
+	entries := l.entries()
+	if len(entries) == 0 {
+		return image.Rectangle{}
+	}
+	_, r := l.layout(entries)
+	return r
+}
+
+// Render draws the Legend with a symbol and the label of each entry.
+func (l *Legend) Render(dst draw.Image) {
+	entries := l.entries()
+	if len(entries) == 0 {
+		return
+	}
+	typer, r := l.layout(entries)
+
+	f := l.Parent.Parent
+	draw.Draw(dst, r, &image.Uniform{l.FillColor}, image.ZP, draw.Over)
+	border(dst, r, f.pxi(1), &image.Uniform{l.StrokeColor}, image.ZP, draw.Src)
+
+	lh := typer.lineHeight()
+	pad := lh / 2
+	sw := 2 * lh
+	m := f.pxi(3)
+	for i, e := range entries {
+		top := r.Min.Y + pad + i*lh
+		cy := top + lh/2
+		x := r.Min.X + pad
+		src := &image.Uniform{e.color}
+
+		switch e.kind {
+		case legendLine:
+			pen.Line(dst, image.Pt(x, cy), image.Pt(x+sw, cy), f.pxi(2), e.color)
+		case legendMarker:
+			draw.Draw(dst, image.Rect(x+sw/2-m, cy-m, x+sw/2+m, cy+m), src, image.ZP, draw.Over)
+		case legendPatch:
+			draw.Draw(dst, image.Rect(x, cy-lh/3, x+sw, cy+lh/3), src, image.ZP, draw.Over)
+		}
+
+		_, h, _, _ := typer.box(e.label)
+		typer.Render(dst, x+sw+pad, cy-h/2, e.label)
+	}
+}
@@ -1,27 +1,146 @@
 package canvas
 
 import (
-	"golang.org/x/image/colornames"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+
+	"github.com/cgxeiji/plt/bag/pen"
 )
 
+// ScatterPoint represents a marker located in data coordinates
+// with Axes as its parent.
+// The Size of a ScatterPoint is defined in points.
 type ScatterPoint struct {
 	primitive
 	Parent *Axes
 	X, Y   float64
 }
 
+// NewScatterPoint creates a new ScatterPoint at (x, y) in data coordinates
+// linked to an Axes.
 func NewScatterPoint(parent *Axes, x, y float64) (*ScatterPoint, error) {
+	parent.Parent.Lock()
+	defer parent.Parent.Unlock()
+	return newScatterPoint(parent, x, y)
+}
+
+func newScatterPoint(parent *Axes, x, y float64) (*ScatterPoint, error) {
 	var point ScatterPoint
 	point.Parent = parent
 	point.X = x
 	point.Y = y
 	point.Origin = [2]float64{x, y}
-	point.Size = [2]float64{0.01, 0.01}
-	point.T = append(point.T, parent.T...)
-	point.T = append(point.T, nil)
+	size := parent.Parent.theme.MarkerSize
+	point.Size = [2]float64{size, size}
+	point.t = parent.dataT
 
-	point.FillColor = colornames.Green
+	point.FillColor = parent.Parent.theme.color(0)
 
 	parent.children = append(parent.children, &point)
 	return &point, nil
 }
+
+// Render draws the ScatterPoint into a draw.Image interface.
+func (p *ScatterPoint) Render(dst draw.Image) {
+	draw.Draw(dst, p.Bounds(), &image.Uniform{p.Color()}, image.ZP, draw.Over)
+}
+
+// Bounds returns the pixels covered by the ScatterPoint's marker
+// centered on its data coordinates.
+func (p *ScatterPoint) Bounds() image.Rectangle {
+	x, y := pixel(p, p.X, p.Y)
+	f := p.Parent.Parent
+	w, h := f.px(p.Size[0]), f.px(p.Size[1])
+	return image.Rect(
+		int(x-w/2), int(y-h/2),
+		int(x+w/2), int(y+h/2),
+	)
+}
+
+// Line represents a polyline in data coordinates with Axes as its parent.
+type Line struct {
+	primitive
+	Parent *Axes
+	X, Y   []float64
+	// W is the width of the Line in points.
+	W int
+	// Downsample is the method used to reduce the points drawn when
+	// the Line has more than Threshold points.
+	Downsample DownsampleMethod
+	Threshold  int
+}
+
+func (l *Line) String() string {
+	return fmt.Sprintf("Line {Points: %v, Width: %v}", len(l.X), l.W)
+}
+
+// newLine creates a new Line through the points X and Y linked to an Axes.
+func newLine(parent *Axes, X, Y []float64) (*Line, error) {
+	if len(X) != len(Y) {
+		return &Line{}, fmt.Errorf(
+			"Dimensions mismatch (X[%v] != Y[%v])",
+			len(X), len(Y))
+	}
+
+	var l Line
+	l.Parent = parent
+	l.X = X
+	l.Y = Y
+	l.W = parent.Parent.theme.LineWidth
+	l.Downsample = M4
+	l.Threshold = DefaultDownsampleThreshold
+	l.t = parent.dataT
+	l.FillColor = parent.Parent.theme.color(0)
+
+	parent.children = append(parent.children, &l)
+	return &l, nil
+}
+
+// Render draws each segment of the Line into a draw.Image interface.
+// The segments are clipped to the bounds of dst, widened by the width
+// of the Line, so zooming into a Line does not draw far outside of it.
+func (l *Line) Render(dst draw.Image) {
+	X, Y := l.pixels()
+	w := l.Parent.Parent.pxi(l.W)
+	r := dst.Bounds().Inset(-w - 1)
+	for i := 1; i < len(X); i++ {
+		x0, y0, x1, y1, ok := clipSegment(X[i-1], Y[i-1], X[i], Y[i], r)
+		if !ok {
+			continue
+		}
+		pen.Line(dst, image.Pt(int(x0), int(y0)), image.Pt(int(x1), int(y1)), w, l.Color())
+	}
+}
+
+// pixels returns the points of the Line in pixels, downsampled if the
+// Line has more than Threshold points.
+// The pixel columns are counted inside the Axes, and the points outside
+// of it are folded into the columns next to its border.
+func (l *Line) pixels() (X, Y []float64) {
+	t := l.Transform()
+	X = make([]float64, len(l.X))
+	Y = make([]float64, len(l.Y))
+	for i := range l.X {
+		X[i], Y[i] = t.Apply(l.X[i], l.Y[i])
+	}
+	if l.Downsample == NoDownsample || len(X) <= l.Threshold {
+		return X, Y
+	}
+
+	b := l.Parent.Bounds()
+	switch l.Downsample {
+	case M4:
+		return downsampleM4(X, Y, b.Min.X, b.Max.X)
+	case MinMax:
+		return downsampleMinMax(X, Y, b.Min.X, b.Max.X)
+	case LTTB:
+		x0, x1 := math.Inf(1), math.Inf(-1)
+		for _, x := range X {
+			x0, x1 = math.Min(x0, x), math.Max(x1, x)
+		}
+		return downsampleLTTB(X, Y, 2*int(x1-x0+1))
+	}
+	return X, Y
+}
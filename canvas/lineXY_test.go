@@ -0,0 +1,63 @@
+package canvas
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+// TestLineZoom draws a Line zoomed a hundred million times, whose
+// segment is far longer than the Axes.
+func TestLineZoom(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	if err := ax.LinePlot([]float64{0, 1e6}, []float64{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	ax.SetXLim(0, 0.01)
+	ax.SetYLim(-1, 1)
+	l := ax.children[0].(*Line)
+
+	done := make(chan *image.RGBA)
+	go func() {
+		done <- renderLine(l)
+	}()
+	select {
+	case dst := <-done:
+		// The Line crosses the Axes at y = 0.
+		b := ax.Bounds()
+		_, y := pixel(l, 0, 0)
+		for _, x := range []int{b.Min.X + 1, b.Max.X - 2} {
+			if _, _, _, a := dst.At(x, int(y)).RGBA(); a == 0 {
+				t.Errorf("Pixel (%v, %v) of the Line not drawn", x, int(y))
+			}
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Render of the zoomed Line did not finish")
+	}
+}
+
+func TestClipSegment(t *testing.T) {
+	r := image.Rect(0, 0, 100, 50)
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		want           [4]float64
+		ok             bool
+	}{
+		{"inside", 10, 10, 20, 30, [4]float64{10, 10, 20, 30}, true},
+		{"crossing", -100, 25, 200, 25, [4]float64{0, 25, 100, 25}, true},
+		{"leaving", 50, 25, 50, 1e9, [4]float64{50, 25, 50, 50}, true},
+		{"outside", 150, 0, 200, 50, [4]float64{}, false},
+		{"short of the border", -50, 10, -1, 10, [4]float64{}, false},
+	}
+	for _, tt := range tests {
+		x0, y0, x1, y1, ok := clipSegment(tt.x0, tt.y0, tt.x1, tt.y1, r)
+		if ok != tt.ok || (ok && [4]float64{x0, y0, x1, y1} != tt.want) {
+			t.Errorf("%v: segment %v %v %v %v %v, want %v %v", tt.name, x0, y0, x1, y1, ok, tt.want, tt.ok)
+		}
+	}
+}
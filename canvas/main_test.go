@@ -0,0 +1,15 @@
+package canvas
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests from the root of the repository, where the
+// default font is found.
+func TestMain(m *testing.M) {
+	if err := os.Chdir(".."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
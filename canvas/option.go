@@ -0,0 +1,172 @@
+package canvas
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// PlotOption sets an optional feature of a plot.
+// PlotOptions are passed to the plotting methods of Axes,
+// for example:
+//  ax.ScatterPlot(X, Y, canvas.YErr(std), canvas.CapSize(8))
+type PlotOption func(*plotConfig)
+
+// plotConfig holds the optional features requested for a plot.
+type plotConfig struct {
+	xerr, yerr  [][]float64
+	capSize     int
+	color       color.Color
+	alpha       float64
+	where       []bool
+	interpolate bool
+	label       string
+	width       int
+	style       LineStyle
+	sizes       []float64
+	colors      []color.Color
+	pie         pieConfig
+	finance     financeConfig
+	annotate    annotateConfig
+	downsample  downsampleConfig
+}
+
+func newPlotConfig(opts []PlotOption) *plotConfig {
+	cfg := &plotConfig{
+		capSize: 6,
+		alpha:   1,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// XErr adds horizontal error bars to a plot.
+// A single slice draws symmetric error bars.
+// Two slices draw asymmetric error bars with the lower and upper errors
+// respectively.
+func XErr(err ...[]float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.xerr = err
+	}
+}
+
+// YErr adds vertical error bars to a plot.
+// A single slice draws symmetric error bars.
+// Two slices draw asymmetric error bars with the lower and upper errors
+// respectively.
+func YErr(err ...[]float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.yerr = err
+	}
+}
+
+// CapSize sets the length in points of the caps drawn at the end of
+// the error bars.
+// A size of 0 removes the caps.
+func CapSize(size int) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.capSize = size
+	}
+}
+
+// Color sets the color of a plot instead of the default one.
+func Color(c color.Color) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.color = c
+	}
+}
+
+// Alpha sets the opacity of a plot between 0 (transparent) and 1 (opaque).
+func Alpha(alpha float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.alpha = alpha
+	}
+}
+
+// Where restricts a filled plot to the regions where mask is true.
+func Where(mask []bool) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.where = mask
+	}
+}
+
+// Interpolate extends the regions restricted by Where up to the point
+// where the filled curves cross each other.
+func Interpolate() PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.interpolate = true
+	}
+}
+
+// LegendLabel names a plot in the Legend of its Axes.
+func LegendLabel(text string) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.label = text
+	}
+}
+
+// colorOr returns the color requested for the plot or def if it is not set.
+// The opacity set with Alpha is applied to the returned color.
+func (cfg *plotConfig) colorOr(def color.Color) color.Color {
+	c := def
+	if cfg.color != nil {
+		c = cfg.color
+	}
+	return withAlpha(c, cfg.alpha)
+}
+
+// bounds returns the lower and upper errors defined by err for n values.
+func bounds(err [][]float64, n int) (lower, upper []float64, e error) {
+	switch len(err) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		lower, upper = err[0], err[0]
+	case 2:
+		lower, upper = err[0], err[1]
+	default:
+		return nil, nil, fmt.Errorf(
+			"Error bars need 1 or 2 slices, got %v", len(err))
+	}
+	if len(lower) != n || len(upper) != n {
+		return nil, nil, fmt.Errorf(
+			"Dimensions mismatch (values[%v] != errors[%v, %v])",
+			n, len(lower), len(upper))
+	}
+	return lower, upper, nil
+}
+
+// checkErrors returns an error if the error bars requested in cfg do not
+// match the points X and Y, so nothing is attached for a wrong plot.
+func (cfg *plotConfig) checkErrors(X, Y []float64) error {
+	if _, _, err := bounds(cfg.xerr, len(X)); err != nil {
+		return err
+	}
+	_, _, err := bounds(cfg.yerr, len(Y))
+	return err
+}
+
+// extent returns V extended with the values reached by the error bars err.
+func extent(V []float64, err [][]float64) []float64 {
+	lower, upper, e := bounds(err, len(V))
+	if e != nil || lower == nil {
+		return V
+	}
+	ext := make([]float64, 0, 3*len(V))
+	ext = append(ext, V...)
+	for i, v := range V {
+		ext = append(ext, v-lower[i], v+upper[i])
+	}
+	return ext
+}
+
+// extentX returns X extended with the horizontal error bars.
+func (cfg *plotConfig) extentX(X []float64) []float64 {
+	return extent(X, cfg.xerr)
+}
+
+// extentY returns Y extended with the vertical error bars.
+func (cfg *plotConfig) extentY(Y []float64) []float64 {
+	return extent(Y, cfg.yerr)
+}
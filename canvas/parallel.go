@@ -0,0 +1,166 @@
+package canvas
+
+import (
+	"image"
+	"sort"
+)
+
+// Layer is a group of children of a Figure that only draw inside Region.
+type Layer struct {
+	Region   image.Rectangle
+	Children []Container
+}
+
+// Layers splits the children of the Figure into Layers that draw on
+// disjoint pixels, so each Layer can be rendered on its own, in any
+// order or concurrently, into the same image after the Figure itself.
+//
+// The children that may draw on the same pixels, such as an Axes with
+// its twins, insets and Legend, are kept in the same Layer in the order
+// they are drawn. The pixels of a child are known for Axes, PolarAxes
+// without fixed radial limits and Legends; any other child, or an Axes
+// with an unclipped child that is not text, takes the whole Figure and
+// ends up in a single Layer with everything else.
+//
+// Layers also brings the cached transformations of every element up to
+// date, so rendering the Layers concurrently only reads them. It must be
+// called holding the lock of the Figure, which is kept until the Layers
+// are rendered.
+func (f *Figure) Layers() []Layer {
+	bounds := f.Bounds()
+
+	var layers []Layer
+	var order [][]int
+	for i, c := range f.children {
+		warm(c)
+		layers = append(layers, Layer{
+			Region:   f.footprint(c).Intersect(bounds),
+			Children: []Container{c},
+		})
+		order = append(order, []int{i})
+	}
+
+	// Merge the Layers that overlap until every Region is disjoint.
+	// A merged Region may reach a Layer that did not overlap any of its
+	// parts, so the search starts again after each merge.
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(layers) && !merged; i++ {
+			for j := i + 1; j < len(layers); j++ {
+				if !layers[i].Region.Overlaps(layers[j].Region) {
+					continue
+				}
+				layers[i].Region = layers[i].Region.Union(layers[j].Region)
+				layers[i].Children = append(layers[i].Children, layers[j].Children...)
+				order[i] = append(order[i], order[j]...)
+				layers = append(layers[:j], layers[j+1:]...)
+				order = append(order[:j], order[j+1:]...)
+				merged = true
+				break
+			}
+		}
+	}
+
+	// Keep the children of each Layer in the order they are drawn.
+	for i := range layers {
+		sort.Sort(byOrder{layers[i].Children, order[i]})
+	}
+
+	return layers
+}
+
+// byOrder sorts children by their index in the Figure.
+type byOrder struct {
+	children []Container
+	index    []int
+}
+
+func (b byOrder) Len() int           { return len(b.children) }
+func (b byOrder) Less(i, j int) bool { return b.index[i] < b.index[j] }
+func (b byOrder) Swap(i, j int) {
+	b.children[i], b.children[j] = b.children[j], b.children[i]
+	b.index[i], b.index[j] = b.index[j], b.index[i]
+}
+
+// warm computes the cached transformation of c and its children.
+func warm(c Container) {
+	if t, ok := c.(interface{ Transform() Affine2D }); ok {
+		t.Transform()
+	}
+	for _, child := range c.Children() {
+		warm(child)
+	}
+}
+
+// footprint returns the pixels a child of the Figure and its children
+// may draw on, or the whole Figure if they are not known.
+func (f *Figure) footprint(c Container) image.Rectangle {
+	switch c := c.(type) {
+	case *Axes:
+		return f.axesFootprint(c, func(child Container) bool {
+			_, ok := c.ClipRect(child)
+			return ok
+		})
+	case *PolarAxes:
+		// The plots of a PolarAxes are not clipped, but they stay inside
+		// it while the radial limits fit the data.
+		if c.rfixed {
+			return f.Bounds()
+		}
+		return f.axesFootprint(c.cart, func(child Container) bool {
+			switch child.(type) {
+			case *Polygon, *Line, *ScatterPoint:
+				return true
+			}
+			return false
+		})
+	case *Legend:
+		return c.Bounds()
+	}
+	return f.Bounds()
+}
+
+// axesFootprint returns the pixels drawn by ax and its children, where
+// inside reports the children drawn only inside the Bounds of ax.
+// The Bounds are widened by the spines, drawn outside of them, and by
+// half the width of the grid lines, centered on the border. The text is
+// widened by a pixel for the glyphs that overhang their box.
+func (f *Figure) axesFootprint(ax *Axes, inside func(Container) bool) image.Rectangle {
+	w := f.pxi(f.theme.SpineWidth)
+	for _, a := range ax.axis {
+		if a != nil {
+			w = max(w, f.pxi(max(a.Grid.Width, a.MinorGrid.Width))/2)
+		}
+	}
+	r := ax.Bounds().Inset(-(w + 1))
+
+	var walk func(c Container) bool
+	walk = func(c Container) bool {
+		switch e := c.(type) {
+		case *Axis:
+			e.prepare()
+		case *Annotation:
+			r = r.Union(e.footprint())
+		case extenter:
+			r = r.Union(e.Extent().Inset(-1))
+		default:
+			return false
+		}
+		for _, child := range c.Children() {
+			if !walk(child) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, child := range ax.Children() {
+		if inside(child) {
+			continue
+		}
+		if !walk(child) {
+			return f.Bounds()
+		}
+	}
+
+	return r
+}
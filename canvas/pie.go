@@ -0,0 +1,196 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+)
+
+// pieConfig holds the optional features requested for a pie chart.
+type pieConfig struct {
+	hole       float64
+	explode    []float64
+	startAngle float64
+	clockwise  bool
+	pctFormat  string
+	pctOutside bool
+}
+
+// Donut turns a pie chart into a donut chart with a hole of radius
+// ratio [0, 1) relative to the radius of the pie.
+func Donut(ratio float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.hole = ratio
+	}
+}
+
+// Explode moves each wedge of a pie chart away from the center
+// by a fraction of the radius.
+func Explode(offsets []float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.explode = offsets
+	}
+}
+
+// StartAngle sets the angle in degrees, counterclockwise from the X axis,
+// where the first wedge of a pie chart starts.
+func StartAngle(deg float64) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.startAngle = deg
+	}
+}
+
+// Clockwise draws the wedges of a pie chart clockwise.
+func Clockwise() PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.clockwise = true
+	}
+}
+
+// AutoPct labels each wedge of a pie chart with its percentage
+// formatted with format, for example "%.1f%%".
+// The percentage is drawn inside the wedge unless PctOutside is set.
+func AutoPct(format string) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.pctFormat = format
+	}
+}
+
+// PctOutside draws the percentage labels of a pie chart outside
+// the wedges, next to the labels.
+func PctOutside() PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.pie.pctOutside = true
+	}
+}
+
+// arc returns the points of an arc of radius r centered at (cx, cy)
+// from the angle t0 to t1 in radians.
+func arc(cx, cy, r, t0, t1 float64) (X, Y []float64) {
+	n := int(math.Ceil(math.Abs(t1-t0)/(math.Pi/90))) + 1
+	for i := 0; i <= n; i++ {
+		t := t0 + (t1-t0)*float64(i)/float64(n)
+		X = append(X, cx+r*math.Cos(t))
+		Y = append(Y, cy+r*math.Sin(t))
+	}
+	return X, Y
+}
+
+// Pie creates a Pie chart inside Axes with the share of each value
+// of the total.
+// Labels are drawn outside the wedges with leader lines and can be nil.
+// The Axes is set to equal aspect so the pie stays round.
+func (ax *Axes) Pie(values []float64, labels []string, opts ...PlotOption) error {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if labels != nil && len(labels) != len(values) {
+		return fmt.Errorf(
+			"Dimensions mismatch (values[%v] != labels[%v])",
+			len(values), len(labels))
+	}
+	cfg := newPlotConfig(opts)
+	pie := cfg.pie
+	if pie.explode != nil && len(pie.explode) != len(values) {
+		return fmt.Errorf(
+			"Dimensions mismatch (values[%v] != explode[%v])",
+			len(values), len(pie.explode))
+	}
+
+	var total float64
+	for _, v := range values {
+		if v < 0 {
+			return fmt.Errorf("Negative value %v in pie chart", v)
+		}
+		total += v
+	}
+	if total == 0 {
+		return fmt.Errorf("Pie chart values add up to zero")
+	}
+
+	dir := 1.0
+	if pie.clockwise {
+		dir = -1
+	}
+	t0 := pie.startAngle * math.Pi / 180
+	var reach float64 = 1
+
+	for i, v := range values {
+		t1 := t0 + dir*2*math.Pi*v/total
+		mid := (t0 + t1) / 2
+		cx, cy := 0.0, 0.0
+		if pie.explode != nil {
+			cx = pie.explode[i] * math.Cos(mid)
+			cy = pie.explode[i] * math.Sin(mid)
+			reach = math.Max(reach, 1+pie.explode[i])
+		}
+
+		X, Y := arc(cx, cy, 1, t0, t1)
+		if pie.hole > 0 {
+			hx, hy := arc(cx, cy, pie.hole, t1, t0)
+			X, Y = append(X, hx...), append(Y, hy...)
+		} else {
+			X, Y = append(X, cx), append(Y, cy)
+		}
+		wedge, err := newPolygon(ax, X, Y)
+		if err != nil {
+			return err
+		}
+		wedge.FillColor = withAlpha(ax.nextColor(), cfg.alpha)
+
+		pct := ""
+		if pie.pctFormat != "" {
+			pct = fmt.Sprintf(pie.pctFormat, 100*v/total)
+		}
+		if pct != "" && !pie.pctOutside {
+			r := (1 + pie.hole) / 2
+			if pie.hole == 0 {
+				r = 0.6
+			}
+			if _, err := newText(ax, cx+r*math.Cos(mid), cy+r*math.Sin(mid), pct); err != nil {
+				return err
+			}
+		}
+
+		text := ""
+		if labels != nil {
+			text = labels[i]
+		}
+		if pct != "" && pie.pctOutside {
+			if text != "" {
+				text += " "
+			}
+			text += "(" + pct + ")"
+		}
+		if text != "" {
+			cos, sin := math.Cos(mid), math.Sin(mid)
+			l, err := newLine(ax,
+				[]float64{cx + cos, cx + 1.1*cos},
+				[]float64{cy + sin, cy + 1.1*sin})
+			if err != nil {
+				return err
+			}
+			l.W = 1
+			l.FillColor = wedge.FillColor
+
+			t, err := newText(ax, cx+1.15*cos, cy+1.15*sin, text)
+			if err != nil {
+				return err
+			}
+			t.NoClip = true
+			switch {
+			case cos > 0.1:
+				t.XAlign = LeftAlign
+			case cos < -0.1:
+				t.XAlign = RightAlign
+			}
+			reach = math.Max(reach, 1.4+math.Hypot(cx, cy))
+		}
+
+		t0 = t1
+	}
+
+	ax.setXLim(-reach, reach)
+	ax.setYLim(-reach, reach)
+	ax.setEqualAspect(true)
+
+	return nil
+}
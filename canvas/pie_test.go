@@ -0,0 +1,162 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPiePlacement(t *testing.T) {
+	// wedge is the expected wedge of a pie chart, from t0 to t1 degrees
+	// around (cx, cy), and its label at a radius r from the center.
+	type wedge struct {
+		t0, t1, cx, cy float64
+		label          string
+		r              float64
+		align          Alignment
+	}
+	tests := []struct {
+		name   string
+		values []float64
+		labels []string
+		opts   []PlotOption
+		hole   float64
+		wedges []wedge
+		reach  float64
+	}{
+		{
+			name:   "labels",
+			values: []float64{1, 1, 2}, labels: []string{"a", "b", "c"},
+			wedges: []wedge{
+				{0, 90, 0, 0, "a", 1.15, LeftAlign},
+				{90, 180, 0, 0, "b", 1.15, RightAlign},
+				{180, 360, 0, 0, "c", 1.15, CenterAlign},
+			},
+			reach: 1.4,
+		},
+		{
+			name:   "start clockwise",
+			values: []float64{1, 1}, labels: []string{"a", "b"},
+			opts: []PlotOption{StartAngle(90), Clockwise()},
+			wedges: []wedge{
+				{90, -90, 0, 0, "a", 1.15, LeftAlign},
+				{-90, -270, 0, 0, "b", 1.15, RightAlign},
+			},
+			reach: 1.4,
+		},
+		{
+			name:   "explode",
+			values: []float64{1, 1}, labels: []string{"a", "b"},
+			opts: []PlotOption{Explode([]float64{0, 0.5})},
+			wedges: []wedge{
+				{0, 180, 0, 0, "a", 1.15, CenterAlign},
+				{180, 360, 0, -0.5, "b", 1.15, CenterAlign},
+			},
+			reach: 1.9,
+		},
+		{
+			name:   "donut percentages",
+			values: []float64{1, 3},
+			opts:   []PlotOption{Donut(0.5), AutoPct("%.0f%%")},
+			hole:   0.5,
+			wedges: []wedge{
+				{0, 90, 0, 0, "25%", 0.75, CenterAlign},
+				{90, 360, 0, 0, "75%", 0.75, CenterAlign},
+			},
+			reach: 1,
+		},
+		{
+			name:   "percentages inside",
+			values: []float64{1, 3},
+			opts:   []PlotOption{AutoPct("%.0f%%")},
+			wedges: []wedge{
+				{0, 90, 0, 0, "25%", 0.6, CenterAlign},
+				{90, 360, 0, 0, "75%", 0.6, CenterAlign},
+			},
+			reach: 1,
+		},
+		{
+			name:   "percentages outside",
+			values: []float64{1, 3}, labels: []string{"a", "b"},
+			opts: []PlotOption{AutoPct("%.0f%%"), PctOutside()},
+			wedges: []wedge{
+				{0, 90, 0, 0, "a (25%)", 1.15, LeftAlign},
+				{90, 360, 0, 0, "b (75%)", 1.15, RightAlign},
+			},
+			reach: 1.4,
+		},
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for _, tt := range tests {
+		fig, err := NewFigure(400, 400)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ax := fig.NewAxes()
+		if err := ax.Pie(tt.values, tt.labels, tt.opts...); err != nil {
+			t.Errorf("%v: %v", tt.name, err)
+			continue
+		}
+
+		var wedges []*Polygon
+		var texts []*Text
+		var leaders int
+		for _, c := range ax.children {
+			switch e := c.(type) {
+			case *Polygon:
+				wedges = append(wedges, e)
+			case *Text:
+				texts = append(texts, e)
+			case *Line:
+				leaders++
+			}
+		}
+		if len(wedges) != len(tt.wedges) || len(texts) != len(tt.wedges) {
+			t.Errorf("%v: %v wedges and %v texts, want %v of each",
+				tt.name, len(wedges), len(texts), len(tt.wedges))
+			continue
+		}
+		if want := len(tt.labels); leaders != want {
+			t.Errorf("%v: %v leader lines, want %v", tt.name, leaders, want)
+		}
+
+		for i, w := range tt.wedges {
+			t0, t1 := w.t0*math.Pi/180, w.t1*math.Pi/180
+			mid := (t0 + t1) / 2
+
+			// The outer arc of a wedge runs from t0 to t1 and closes on
+			// the center, or on the hole from t1 back to t0.
+			p := wedges[i]
+			end, last := len(p.X)-2, [2]float64{w.cx, w.cy}
+			if tt.hole > 0 {
+				end = len(p.X)/2 - 1
+				last = [2]float64{w.cx + tt.hole*math.Cos(t0), w.cy + tt.hole*math.Sin(t0)}
+			}
+			got := [][2]float64{{p.X[0], p.Y[0]}, {p.X[end], p.Y[end]}, {p.X[len(p.X)-1], p.Y[len(p.Y)-1]}}
+			want := [][2]float64{
+				{w.cx + math.Cos(t0), w.cy + math.Sin(t0)},
+				{w.cx + math.Cos(t1), w.cy + math.Sin(t1)},
+				last,
+			}
+			for j := range got {
+				if !near(got[j][0], want[j][0]) || !near(got[j][1], want[j][1]) {
+					t.Errorf("%v: wedge %v through %v, want %v", tt.name, i, got, want)
+					break
+				}
+			}
+
+			l := texts[i]
+			x, y := w.cx+w.r*math.Cos(mid), w.cy+w.r*math.Sin(mid)
+			if l.Text != w.label || !near(l.X, x) || !near(l.Y, y) || l.XAlign != w.align {
+				t.Errorf("%v: label %q at (%v, %v) aligned %v, want %q at (%v, %v) aligned %v",
+					tt.name, l.Text, l.X, l.Y, l.XAlign, w.label, x, y, w.align)
+			}
+		}
+
+		lim := [2]float64{-tt.reach, tt.reach}
+		if !ax.equal || !near(ax.limits[0][0], lim[0]) || !near(ax.limits[0][1], lim[1]) ||
+			ax.limits[1] != ax.limits[0] {
+			t.Errorf("%v: limits %v, want %v with an equal aspect", tt.name, ax.limits, lim)
+		}
+	}
+}
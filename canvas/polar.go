@@ -0,0 +1,295 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// PolarAxes represents an Axes in polar coordinates with Figure as its parent.
+//
+// Plots inside a PolarAxes take the angles theta in radians and the radii r.
+// The radial limits grow to fit every plot attached to it, unless they are
+// fixed with SetRLim.
+type PolarAxes struct {
+	// RLim holds the radial limits mapped from the center to the border.
+	RLim [2]float64
+	// ThetaZero is the location of theta = 0 in degrees,
+	// counterclockwise from the right of the PolarAxes.
+	ThetaZero float64
+	// Clockwise makes theta increase clockwise.
+	Clockwise bool
+	Parent    *Figure
+
+	cart   *Axes
+	rdata  float64
+	rfixed bool
+	series []*polarSeries
+}
+
+// Kinds of plots drawn by a PolarAxes.
+const (
+	polarLine = iota
+	polarScatter
+	polarBar
+)
+
+// polarSeries holds the data of a plot inside a PolarAxes, so it can be
+// located again whenever the PolarAxes changes.
+type polarSeries struct {
+	kind     int
+	theta, r []float64
+	width    []float64
+	color    color.Color
+}
+
+// NewPolarAxes attaches a new PolarAxes into the Figure.
+func (f *Figure) NewPolarAxes() *PolarAxes {
+	f.Lock()
+	defer f.Unlock()
+	axes, _ := f.subAxes(1, 1)
+	return newPolarAxes(axes[0])
+}
+
+// newPolarAxes turns a Cartesian Axes into the canvas of a PolarAxes
+// and takes its place in the parent Figure.
+func newPolarAxes(ax *Axes) *PolarAxes {
+	pa := &PolarAxes{
+		RLim:   [2]float64{0, 1},
+		Parent: ax.Parent,
+		cart:   ax,
+	}
+	for i, c := range ax.Parent.children {
+		if c == ax {
+			ax.Parent.children[i] = pa
+		}
+	}
+
+	ax.FillColor = color.Transparent
+	ax.setXLim(-1.2, 1.2)
+	ax.setYLim(-1.2, 1.2)
+	ax.setEqualAspect(true)
+
+	pa.layout()
+	return pa
+}
+
+// Render does nothing, the background and grids of the PolarAxes
+// are drawn by its children.
+func (pa *PolarAxes) Render(dst draw.Image) {}
+
+// Children returns a slice of Container from the children of the PolarAxes.
+func (pa *PolarAxes) Children() []Container {
+	return pa.cart.Children()
+}
+
+// Bounds returns the bounds of the PolarAxes in pixels.
+func (pa *PolarAxes) Bounds() image.Rectangle {
+	return pa.cart.Bounds()
+}
+
+func (pa *PolarAxes) String() string {
+	return fmt.Sprintf("PolarAxes {RLim: %v, ThetaZero: %v, Clockwise: %v, Bounds: %v}",
+		pa.RLim, pa.ThetaZero, pa.Clockwise, pa.Bounds())
+}
+
+// SetRLim fixes the radial limits of the PolarAxes.
+func (pa *PolarAxes) SetRLim(min, max float64) {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	pa.RLim = [2]float64{min, max}
+	pa.rfixed = true
+	pa.layout()
+}
+
+// SetThetaZero sets the location of theta = 0 in degrees,
+// counterclockwise from the right of the PolarAxes.
+// For example, 90 places theta = 0 at the top.
+func (pa *PolarAxes) SetThetaZero(deg float64) {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	pa.ThetaZero = deg
+	pa.layout()
+}
+
+// SetClockwise sets the direction in which theta increases.
+func (pa *PolarAxes) SetClockwise(clockwise bool) {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	pa.Clockwise = clockwise
+	pa.layout()
+}
+
+// project returns the Cartesian coordinates of the polar point (theta, r)
+// inside the canvas of the PolarAxes.
+func (pa *PolarAxes) project(theta, r float64) (float64, float64) {
+	rn := (r - pa.RLim[0]) / (pa.RLim[1] - pa.RLim[0])
+	if rn < 0 {
+		rn = 0
+	}
+	dir := 1.0
+	if pa.Clockwise {
+		dir = -1
+	}
+	a := pa.ThetaZero*math.Pi/180 + dir*theta
+	return rn * math.Cos(a), rn * math.Sin(a)
+}
+
+// projectAll returns the Cartesian coordinates of the polar points.
+// Each segment is split into steps of at most 2 degrees, so it follows
+// the curvature of the polar space.
+func (pa *PolarAxes) projectAll(theta, r []float64) (X, Y []float64) {
+	for i := range theta {
+		n := 1
+		if i > 0 {
+			n = int(math.Ceil(math.Abs(theta[i]-theta[i-1]) / (math.Pi / 90)))
+			if n < 1 {
+				n = 1
+			}
+		}
+		for j := 1; j <= n; j++ {
+			t, rr := theta[i], r[i]
+			if i > 0 {
+				f := float64(j) / float64(n)
+				t = theta[i-1] + f*(theta[i]-theta[i-1])
+				rr = r[i-1] + f*(r[i]-r[i-1])
+			}
+			x, y := pa.project(t, rr)
+			X = append(X, x)
+			Y = append(Y, y)
+		}
+	}
+	return X, Y
+}
+
+// add stores a new plot inside the PolarAxes and relocates every plot.
+func (pa *PolarAxes) add(s *polarSeries, cfg *plotConfig) {
+	s.color = cfg.colorOr(pa.cart.nextColor())
+	pa.series = append(pa.series, s)
+	for _, r := range s.r {
+		pa.rdata = math.Max(pa.rdata, r)
+	}
+	if !pa.rfixed && pa.rdata > 0 {
+		pa.RLim = [2]float64{0, pa.rdata * 1.05}
+	}
+	pa.layout()
+}
+
+// layout replaces the children of the PolarAxes with the grids and plots
+// located with the current limits and orientation.
+func (pa *PolarAxes) layout() {
+	ax := pa.cart
+	ax.children = nil
+
+	// Background
+	X, Y := arc(0, 0, 1, 0, 2*math.Pi)
+	bg, _ := newPolygon(ax, X, Y)
+	theme := ax.Parent.theme
+	bg.FillColor = theme.AxesColor
+
+	// Radial grid
+	for _, r := range niceTicks(pa.RLim[0], pa.RLim[1], 4) {
+		rn := (r - pa.RLim[0]) / (pa.RLim[1] - pa.RLim[0])
+		if rn <= 0 || rn >= 1 {
+			continue
+		}
+		X, Y := arc(0, 0, rn, 0, 2*math.Pi)
+		l, _ := newLine(ax, X, Y)
+		l.W = 1
+		l.FillColor = theme.Grid.Color
+
+		x, y := pa.project(math.Pi/8, r)
+		t, _ := newText(ax, x, y, fmt.Sprintf("%.2f", r))
+		t.H = 0.035
+		t.XAlign = LeftAlign
+		t.YAlign = BottomAlign
+	}
+
+	// Angular grid
+	for deg := 0; deg < 360; deg += 45 {
+		theta := float64(deg) * math.Pi / 180
+		x, y := pa.project(theta, pa.RLim[1])
+		l, _ := newLine(ax, []float64{0, x}, []float64{0, y})
+		l.W = 1
+		l.FillColor = theme.Grid.Color
+
+		t, _ := newText(ax, 1.1*x, 1.1*y, fmt.Sprintf("%v°", deg))
+		t.H = 0.035
+		t.NoClip = true
+	}
+
+	X, Y = arc(0, 0, 1, 0, 2*math.Pi)
+	border, _ := newLine(ax, X, Y)
+	border.FillColor = theme.SpineColor
+
+	for _, s := range pa.series {
+		switch s.kind {
+		case polarLine:
+			X, Y := pa.projectAll(s.theta, s.r)
+			l, _ := newLine(ax, X, Y)
+			l.FillColor = s.color
+		case polarScatter:
+			for i := range s.theta {
+				x, y := pa.project(s.theta[i], s.r[i])
+				p, _ := newScatterPoint(ax, x, y)
+				p.FillColor = s.color
+			}
+		case polarBar:
+			for i := range s.theta {
+				t0, t1 := s.theta[i]-s.width[i]/2, s.theta[i]+s.width[i]/2
+				X, Y := pa.projectAll([]float64{t0, t1}, []float64{s.r[i], s.r[i]})
+				x0, y0 := pa.project(0, pa.RLim[0])
+				X, Y = append(X, x0), append(Y, y0)
+				p, _ := newPolygon(ax, X, Y)
+				p.FillColor = s.color
+			}
+		}
+	}
+}
+
+// LinePlot creates a Line chart inside PolarAxes with theta and r values.
+func (pa *PolarAxes) LinePlot(theta, r []float64, opts ...PlotOption) error {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	if len(theta) != len(r) {
+		return fmt.Errorf(
+			"Dimensions mismatch (theta[%v] != r[%v])",
+			len(theta), len(r))
+	}
+	pa.add(&polarSeries{kind: polarLine, theta: theta, r: r}, newPlotConfig(opts))
+	return nil
+}
+
+// ScatterPlot creates a Scatter chart inside PolarAxes with theta and r values.
+func (pa *PolarAxes) ScatterPlot(theta, r []float64, opts ...PlotOption) error {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	if len(theta) != len(r) {
+		return fmt.Errorf(
+			"Dimensions mismatch (theta[%v] != r[%v])",
+			len(theta), len(r))
+	}
+	pa.add(&polarSeries{kind: polarScatter, theta: theta, r: r}, newPlotConfig(opts))
+	return nil
+}
+
+// Bar creates a Bar chart inside PolarAxes with wedges centered at theta,
+// reaching r, and with an angular width in radians.
+// Bars of equal width covering the whole circle make a rose diagram.
+func (pa *PolarAxes) Bar(theta, r, width []float64, opts ...PlotOption) error {
+	pa.Parent.Lock()
+	defer pa.Parent.Unlock()
+	if len(theta) != len(r) || len(theta) != len(width) {
+		return fmt.Errorf(
+			"Dimensions mismatch (theta[%v] != r[%v] != width[%v])",
+			len(theta), len(r), len(width))
+	}
+	cfg := newPlotConfig(opts)
+	if cfg.alpha == 1 {
+		cfg.alpha = 0.8
+	}
+	pa.add(&polarSeries{kind: polarBar, theta: theta, r: r, width: width}, cfg)
+	return nil
+}
@@ -0,0 +1,135 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolarProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		rlim      [2]float64
+		zero      float64
+		clockwise bool
+		theta, r  float64
+		x, y      float64
+	}{
+		{"center", [2]float64{0, 1}, 0, false, 1, 0, 0, 0},
+		{"border", [2]float64{0, 1}, 0, false, 0, 1, 1, 0},
+		{"quarter", [2]float64{0, 2}, 0, false, math.Pi / 2, 1, 0, 0.5},
+		{"zero at top", [2]float64{0, 1}, 90, false, 0, 1, 0, 1},
+		{"clockwise", [2]float64{0, 1}, 0, true, math.Pi / 2, 1, 0, -1},
+		{"clockwise zero at top", [2]float64{0, 1}, 90, true, math.Pi / 2, 1, 1, 0},
+		{"offset limits", [2]float64{1, 3}, 0, false, math.Pi, 2, -0.5, 0},
+		{"below limits", [2]float64{1, 3}, 0, false, math.Pi, 0, 0, 0},
+		{"beyond limits", [2]float64{0, 1}, 0, false, 0, 2, 2, 0},
+	}
+	for _, tt := range tests {
+		pa := &PolarAxes{RLim: tt.rlim, ThetaZero: tt.zero, Clockwise: tt.clockwise}
+		x, y := pa.project(tt.theta, tt.r)
+		if math.Abs(x-tt.x) > 1e-9 || math.Abs(y-tt.y) > 1e-9 {
+			t.Errorf("%v: project(%v, %v) = (%v, %v), want (%v, %v)",
+				tt.name, tt.theta, tt.r, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestPolarProjectAll(t *testing.T) {
+	pa := &PolarAxes{RLim: [2]float64{0, 1}}
+	tests := []struct {
+		name     string
+		theta, r []float64
+		// n is the number of points and rn the radius of the middle one.
+		n  int
+		rn float64
+	}{
+		{"empty", nil, nil, 0, 0},
+		{"point", []float64{1}, []float64{0.5}, 1, 0.5},
+		{"radial", []float64{1, 1}, []float64{0, 1}, 2, 1},
+		{"half turn", []float64{0, math.Pi}, []float64{1, 1}, 91, 1},
+		{"backwards spiral", []float64{math.Pi, 0}, []float64{1, 0}, 91, 0.5},
+	}
+	for _, tt := range tests {
+		X, Y := pa.projectAll(tt.theta, tt.r)
+		if len(X) != tt.n || len(Y) != tt.n {
+			t.Errorf("%v: %v points, want %v", tt.name, len(X), tt.n)
+			continue
+		}
+		if tt.n == 0 {
+			continue
+		}
+		i := tt.n / 2
+		if rn := math.Hypot(X[i], Y[i]); math.Abs(rn-tt.rn) > 1e-9 {
+			t.Errorf("%v: middle point at radius %v, want %v", tt.name, rn, tt.rn)
+		}
+		last := len(tt.theta) - 1
+		x, y := pa.project(tt.theta[last], tt.r[last])
+		if math.Abs(X[tt.n-1]-x) > 1e-9 || math.Abs(Y[tt.n-1]-y) > 1e-9 {
+			t.Errorf("%v: ends at (%v, %v), want (%v, %v)", tt.name, X[tt.n-1], Y[tt.n-1], x, y)
+		}
+	}
+}
+
+func TestPolarGrid(t *testing.T) {
+	tests := []struct {
+		name      string
+		zero      float64
+		clockwise bool
+		// label is the angular label expected at the right of the PolarAxes.
+		label string
+	}{
+		{"default", 0, false, "0°"},
+		{"zero at top", 90, false, "270°"},
+		{"clockwise zero at top", 90, true, "90°"},
+	}
+	for _, tt := range tests {
+		fig, err := NewFigure(400, 400)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pa := fig.NewPolarAxes()
+		pa.SetRLim(0, 4)
+		pa.SetThetaZero(tt.zero)
+		pa.SetClockwise(tt.clockwise)
+
+		var spokes, circles, right int
+		radial := map[string]bool{}
+		for _, c := range pa.Children() {
+			switch e := c.(type) {
+			case *Line:
+				if len(e.X) == 2 {
+					spokes++
+					if r := math.Hypot(e.X[1], e.Y[1]); math.Abs(r-1) > 1e-9 {
+						t.Errorf("%v: spoke reaches radius %v, want 1", tt.name, r)
+					}
+					continue
+				}
+				if r := math.Hypot(e.X[0], e.Y[0]); r < 1 {
+					circles++
+				}
+			case *Text:
+				if e.XAlign == LeftAlign {
+					radial[e.Text] = true
+					continue
+				}
+				if math.Abs(e.X-1.1) < 1e-9 && math.Abs(e.Y) < 1e-9 {
+					right++
+					if e.Text != tt.label {
+						t.Errorf("%v: %q at the right, want %q", tt.name, e.Text, tt.label)
+					}
+				}
+			}
+		}
+		if spokes != 8 || right != 1 {
+			t.Errorf("%v: %v spokes and %v labels at the right, want 8 and 1", tt.name, spokes, right)
+		}
+		if circles != 3 {
+			t.Errorf("%v: %v radial grid lines, want 3", tt.name, circles)
+		}
+		for _, l := range []string{"1.00", "2.00", "3.00"} {
+			if !radial[l] {
+				t.Errorf("%v: no radial label %q in %v", tt.name, l, radial)
+			}
+		}
+	}
+}
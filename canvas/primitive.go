@@ -5,8 +5,6 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
-
-	"gonum.org/v1/gonum/mat"
 )
 
 // Alignment defines how to draw each element of the plot.
@@ -28,47 +26,15 @@ const (
 )
 
 // transformer is an interface that makes sure the Primitive returns
-// a vector transformed into pixels and its transformation matrix.
+// its transformation into pixels.
 type transformer interface {
-	Vector() mat.Matrix
-	Transform() []*mat.Dense
-}
-
-// Auxiliary function to pretty print matrices.
-func mp(name string, X mat.Matrix) {
-	f := mat.Formatted(X, mat.Prefix(" "), mat.Squeeze())
-	fmt.Printf("%v \n % v\n", name, f)
+	Transform() Affine2D
 }
 
-// Auxiliary function to pretty print matrices.
-func ms(X mat.Matrix) fmt.Formatter {
-	return mat.Formatted(X, mat.Prefix(" "), mat.Squeeze())
-}
-
-// iM defines an identity matrix.
-var iM = mat.NewDense(3, 3, []float64{
-	1, 0, 0,
-	0, 1, 0,
-	0, 0, 1})
-
-func transform(t transformer) *mat.Dense {
-	v := t.Vector()
-	r, c := v.Dims()
-	render := mat.NewDense(r, c, nil)
-
-	trans := mat.DenseCopyOf(iM)
-	transforms := t.Transform()
-	l := len(transforms) - 1
-	for i, m := range transforms {
-		if i == l {
-			break
-		}
-		trans.Product(trans, m)
-	}
-
-	render.Product(trans, v)
-
-	return render
+// pixel transforms the point (x, y), given in the coordinates system of
+// the Primitive, into pixels.
+func pixel(t transformer, x, y float64) (float64, float64) {
+	return t.Transform().Apply(x, y)
 }
 
 // primitive is the building block of the plotter.
@@ -81,44 +47,52 @@ func transform(t transformer) *mat.Dense {
 // Any primitive can contain other primitives
 // as a slice of Container in children.
 type primitive struct {
-	Origin, Size           [2]float64
-	T                      []*mat.Dense
+	Origin, Size [2]float64
+	// t maps the coordinates of the primitive, given by its parent,
+	// into pixels.
+	t                      *transform
 	FillColor, StrokeColor color.Color
 	XAlign, YAlign         Alignment
-	children               []Container
+	// NoClip lets the primitive draw outside of the clip rectangle
+	// set by its parent, such as the data area of an Axes.
+	NoClip bool
+
+	children []Container
+}
+
+// clipped reports whether the primitive is restricted to the clip
+// rectangle of its parent.
+func (p *primitive) clipped() bool {
+	return !p.NoClip
 }
 
-// Vector returns a mat.Matrix with two point coordinates that define
-// the bounding rectangle of the Primitive.
+// corners returns the two corners that define the bounding rectangle
+// of the Primitive.
 //
 // The coordinates system are relative to the Primitive's parent.
-func (p *primitive) Vector() mat.Matrix {
-	var v []float64
+func (p *primitive) corners() (x0, y0, x1, y1 float64) {
 	switch p.XAlign {
 	case CenterAlign:
-		v = append(v, p.Origin[0]-p.Size[0]/2, p.Origin[0]+p.Size[0]/2)
+		x0, x1 = p.Origin[0]-p.Size[0]/2, p.Origin[0]+p.Size[0]/2
 	case RightAlign:
-		v = append(v, p.Origin[0]-p.Size[0], p.Origin[0])
+		x0, x1 = p.Origin[0]-p.Size[0], p.Origin[0]
 	case LeftAlign:
-		v = append(v, p.Origin[0], p.Origin[0]+p.Size[0])
+		x0, x1 = p.Origin[0], p.Origin[0]+p.Size[0]
 	}
 	switch p.YAlign {
 	case CenterAlign:
-		v = append(v, p.Origin[1]-p.Size[1]/2, p.Origin[1]+p.Size[1]/2)
+		y0, y1 = p.Origin[1]-p.Size[1]/2, p.Origin[1]+p.Size[1]/2
 	case TopAlign:
-		v = append(v, p.Origin[1]-p.Size[1], p.Origin[1])
+		y0, y1 = p.Origin[1]-p.Size[1], p.Origin[1]
 	case BottomAlign:
-		v = append(v, p.Origin[1], p.Origin[1]+p.Size[1])
+		y0, y1 = p.Origin[1], p.Origin[1]+p.Size[1]
 	}
-	v = append(v, 1, 1)
-	vec := mat.NewDense(3, 2, v)
-	// mp("V =", vec)
-	return vec
+	return
 }
 
-// Transform returns the transformation matrix of the Primitive.
-func (p *primitive) Transform() []*mat.Dense {
-	return p.T
+// Transform returns the transformation of the Primitive into pixels.
+func (p *primitive) Transform() Affine2D {
+	return p.t.pixels()
 }
 
 // Render draws the Primitive into a draw.Image interface.
@@ -144,14 +118,15 @@ func max(a, b int) int {
 func (p *primitive) Bounds() image.Rectangle {
 	var x0, y0, x1, y1 int
 
-	v := transform(p)
+	t := p.Transform()
+	cx0, cy0, cx1, cy1 := p.corners()
+	fx0, fy0 := t.Apply(cx0, cy0)
+	fx1, fy1 := t.Apply(cx1, cy1)
 
-	x0 = int(v.At(0, 0))
-	y0 = int(v.At(1, 0))
-	x1 = int(v.At(0, 1))
-	y1 = int(v.At(1, 1))
-
-	// fmt.Println("x0:", x0, "y0:", y0, "x1:", x1, "y1:", y1)
+	x0 = int(fx0)
+	y0 = int(fy0)
+	x1 = int(fx1)
+	y1 = int(fy1)
 
 	return image.Rect(min(x0, x1), min(y0, y1), max(x0, x1), max(y0, y1))
 }
@@ -165,7 +140,7 @@ func (p *primitive) String() string {
 	b := p.Bounds()
 	return fmt.Sprintf(
 		"Primitive {T: %v, Origin: %v (pixels: %v), Size: %v (pixels: %v)}",
-		p.T, p.Origin, b.Min, p.Size, b.Size(),
+		p.Transform(), p.Origin, b.Min, p.Size, b.Size(),
 	)
 }
 
@@ -180,3 +155,40 @@ type Container interface {
 	Render(draw.Image)
 	Children() []Container
 }
+
+// Clipper is implemented by the Containers that restrict the pixels
+// where their children draw.
+type Clipper interface {
+	// ClipRect returns the pixels where child can draw, or false if
+	// child is not clipped.
+	ClipRect(child Container) (image.Rectangle, bool)
+}
+
+// Clip returns an image that only draws into the pixels of dst inside r,
+// keeping the coordinates of dst.
+func Clip(dst draw.Image, r image.Rectangle) draw.Image {
+	if s, ok := dst.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		if sub, ok := s.SubImage(r).(draw.Image); ok {
+			return sub
+		}
+	}
+	return &clipImage{dst, r.Intersect(dst.Bounds())}
+}
+
+// clipImage is a draw.Image that ignores the pixels outside of clip.
+type clipImage struct {
+	draw.Image
+	clip image.Rectangle
+}
+
+func (c *clipImage) Bounds() image.Rectangle {
+	return c.clip
+}
+
+func (c *clipImage) Set(x, y int, col color.Color) {
+	if image.Pt(x, y).In(c.clip) {
+		c.Image.Set(x, y, col)
+	}
+}
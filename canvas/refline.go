@@ -0,0 +1,278 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/cgxeiji/plt/bag/pen"
+)
+
+// LineWidth sets the width in points of a reference line.
+func LineWidth(w int) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.width = w
+	}
+}
+
+// Dashes sets the LineStyle of a reference line.
+func Dashes(style LineStyle) PlotOption {
+	return func(cfg *plotConfig) {
+		cfg.style = style
+	}
+}
+
+// RefLine represents an infinite line through the point (X, Y) in data
+// coordinates with Axes as its parent.
+// The line crosses the whole Axes and follows any change of its limits.
+type RefLine struct {
+	primitive
+	Parent *Axes
+	X, Y   float64
+	// Slope is the slope of the line in data units.
+	// Horizontal lines have a Slope of 0 and vertical lines an infinite Slope.
+	Slope float64
+	// W is the width of the line in points.
+	W     int
+	Style LineStyle
+}
+
+// newRefLine creates a new RefLine through (x, y) linked to an Axes.
+// The point must be finite; an infinite slope makes a vertical line.
+func newRefLine(parent *Axes, x, y, slope float64, cfg *plotConfig) (*RefLine, error) {
+	if !finite(x) || !finite(y) || math.IsNaN(slope) {
+		return nil, fmt.Errorf("Line through (%v, %v) with slope %v not valid", x, y, slope)
+	}
+
+	var l RefLine
+	l.Parent = parent
+	l.X, l.Y = x, y
+	l.Slope = slope
+	l.W = 1
+	if cfg.width > 0 {
+		l.W = cfg.width
+	}
+	l.Style = cfg.style
+	l.t = parent.dataT
+	l.FillColor = cfg.colorOr(parent.nextColor())
+	parent.addEntry(cfg, l.FillColor, legendLine)
+
+	parent.children = append(parent.children, &l)
+	return &l, nil
+}
+
+// HLine attaches a horizontal line at y in data coordinates across
+// the whole width of the Axes.
+func (ax *Axes) HLine(y float64, opts ...PlotOption) (*RefLine, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	l, err := newRefLine(ax, 0, y, 0, newPlotConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	ax.extend(nil, []float64{y})
+	return l, nil
+}
+
+// VLine attaches a vertical line at x in data coordinates across
+// the whole height of the Axes.
+func (ax *Axes) VLine(x float64, opts ...PlotOption) (*RefLine, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	l, err := newRefLine(ax, x, 0, math.Inf(1), newPlotConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	ax.extend([]float64{x}, nil)
+	return l, nil
+}
+
+// SlopeLine attaches a line through the point (x, y) in data
+// coordinates with slope in data units, crossing the whole Axes.
+func (ax *Axes) SlopeLine(x, y, slope float64, opts ...PlotOption) (*RefLine, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	if math.IsInf(slope, 0) {
+		return nil, fmt.Errorf("Line through (%v, %v) with slope %v not valid", x, y, slope)
+	}
+	l, err := newRefLine(ax, x, y, slope, newPlotConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	ax.extend([]float64{x}, []float64{y})
+	return l, nil
+}
+
+// Render draws the RefLine from border to border of its Axes.
+func (l *RefLine) Render(dst draw.Image) {
+	ax := l.Parent
+	b := ax.Bounds()
+	w := ax.Parent.pxi(l.W)
+	switch {
+	case l.Slope == 0:
+		_, y := pixel(l, l.X, l.Y)
+		styledLine(dst, image.Pt(b.Min.X, int(y)), image.Pt(b.Max.X, int(y)), w, l.Style, l.Color())
+	case math.IsInf(l.Slope, 0):
+		x, _ := pixel(l, l.X, l.Y)
+		styledLine(dst, image.Pt(int(x), b.Min.Y), image.Pt(int(x), b.Max.Y), w, l.Style, l.Color())
+	default:
+		// The direction is scaled down for steep slopes, so it stays
+		// finite in pixels.
+		dx, dy := 1.0, l.Slope
+		if s := math.Abs(l.Slope); s > 1 {
+			dx, dy = 1/s, l.Slope/s
+		}
+		t := l.Transform()
+		x, y := t.Apply(l.X, l.Y)
+		x0, y0, x1, y1, ok := clipLine(x, y, t.A*dx+t.C*dy, t.B*dx+t.D*dy, b)
+		if !ok {
+			return
+		}
+		dashedLine(dst, x0, y0, x1, y1, w, l.Style, l.Color())
+	}
+}
+
+// clipLine returns the segment inside r of the infinite line through
+// (x, y) with direction (dx, dy).
+// It reports false if the line misses r.
+func clipLine(x, y, dx, dy float64, r image.Rectangle) (x0, y0, x1, y1 float64, ok bool) {
+	return liangBarsky(x, y, dx, dy, math.Inf(-1), math.Inf(1), r)
+}
+
+// clipSegment returns the part inside r of the segment from (x0, y0)
+// to (x1, y1).
+// It reports false if the segment misses r.
+func clipSegment(x0, y0, x1, y1 float64, r image.Rectangle) (cx0, cy0, cx1, cy1 float64, ok bool) {
+	return liangBarsky(x0, y0, x1-x0, y1-y0, 0, 1, r)
+}
+
+// liangBarsky returns the points (x, y) + t*(dx, dy) inside r with t
+// between t0 and t1, using the Liang–Barsky algorithm.
+// It reports false if they miss r or are not finite.
+func liangBarsky(x, y, dx, dy, t0, t1 float64, r image.Rectangle) (x0, y0, x1, y1 float64, ok bool) {
+	for _, e := range [4][2]float64{
+		{-dx, x - float64(r.Min.X)},
+		{dx, float64(r.Max.X) - x},
+		{-dy, y - float64(r.Min.Y)},
+		{dy, float64(r.Max.Y) - y},
+	} {
+		p, q := e[0], e[1]
+		if p == 0 {
+			if q < 0 {
+				return 0, 0, 0, 0, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+	}
+	if !(t0 <= t1) || math.IsInf(t0, 0) || math.IsInf(t1, 0) {
+		return 0, 0, 0, 0, false
+	}
+	return x + t0*dx, y + t0*dy, x + t1*dx, y + t1*dy, true
+}
+
+// dashedLine draws a line in any direction from (x0, y0) to (x1, y1)
+// with the dash pattern of style.
+func dashedLine(dst draw.Image, x0, y0, x1, y1 float64, w int, style LineStyle, col color.Color) {
+	pattern := style.pattern(w)
+	if pattern == nil {
+		pen.Line(dst, image.Pt(int(x0), int(y0)), image.Pt(int(x1), int(y1)), w, col)
+		return
+	}
+
+	length := math.Hypot(x1-x0, y1-y0)
+	ux, uy := (x1-x0)/length, (y1-y0)/length
+	for i, d := 0, 0.0; d < length; i = (i + 1) % len(pattern) {
+		e := math.Min(d+float64(pattern[i]), length)
+		if i%2 == 0 {
+			pen.Line(dst,
+				image.Pt(int(x0+ux*d), int(y0+uy*d)),
+				image.Pt(int(x0+ux*e), int(y0+uy*e)),
+				w, col)
+		}
+		d = e
+	}
+}
+
+// Span represents a band between Min and Max in data coordinates
+// with Axes as its parent.
+// The band crosses the whole Axes and follows any change of its limits.
+type Span struct {
+	primitive
+	Parent   *Axes
+	Min, Max float64
+	// Vertical spans are bands of X values; otherwise, the band holds
+	// Y values.
+	Vertical bool
+}
+
+// newSpan creates a new Span between the finite values v0 and v1 linked
+// to an Axes.
+func newSpan(parent *Axes, v0, v1 float64, vertical bool, opts []PlotOption) (*Span, error) {
+	if !finite(v0) || !finite(v1) {
+		return nil, fmt.Errorf("Span [%v, %v] not valid", v0, v1)
+	}
+	cfg := newPlotConfig(append([]PlotOption{Alpha(0.3)}, opts...))
+
+	var s Span
+	s.Parent = parent
+	s.Min, s.Max = math.Min(v0, v1), math.Max(v0, v1)
+	s.Vertical = vertical
+	s.t = parent.dataT
+	s.FillColor = cfg.colorOr(parent.nextColor())
+	parent.addEntry(cfg, s.FillColor, legendPatch)
+
+	parent.children = append(parent.children, &s)
+	return &s, nil
+}
+
+// HSpan attaches a horizontal band between y0 and y1 in data
+// coordinates across the whole width of the Axes.
+func (ax *Axes) HSpan(y0, y1 float64, opts ...PlotOption) (*Span, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	s, err := newSpan(ax, y0, y1, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	ax.extend(nil, []float64{y0, y1})
+	return s, nil
+}
+
+// VSpan attaches a vertical band between x0 and x1 in data
+// coordinates across the whole height of the Axes.
+func (ax *Axes) VSpan(x0, x1 float64, opts ...PlotOption) (*Span, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	s, err := newSpan(ax, x0, x1, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	ax.extend([]float64{x0, x1}, nil)
+	return s, nil
+}
+
+// Bounds returns the pixels covered by the Span inside its Axes.
+func (s *Span) Bounds() image.Rectangle {
+	b := s.Parent.Bounds()
+	if s.Vertical {
+		x0, _ := pixel(s, s.Min, 0)
+		x1, _ := pixel(s, s.Max, 0)
+		return image.Rect(int(x0), b.Min.Y, int(x1), b.Max.Y)
+	}
+	_, y0 := pixel(s, 0, s.Min)
+	_, y1 := pixel(s, 0, s.Max)
+	return image.Rect(b.Min.X, int(y1), b.Max.X, int(y0))
+}
+
+// Render draws the Span into a draw.Image interface.
+func (s *Span) Render(dst draw.Image) {
+	draw.Draw(dst, s.Bounds(), &image.Uniform{s.Color()}, image.ZP, draw.Over)
+}
@@ -0,0 +1,108 @@
+package canvas
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestClipLine(t *testing.T) {
+	r := image.Rect(0, 0, 100, 50)
+	tests := []struct {
+		name           string
+		x, y, dx, dy   float64
+		x0, y0, x1, y1 float64
+		ok             bool
+	}{
+		{"horizontal", 20, 10, 1, 0, 0, 10, 100, 10, true},
+		{"vertical", 20, 10, 0, -3, 20, 50, 20, 0, true},
+		{"diagonal", 0, 0, 1, 1, 0, 0, 50, 50, true},
+		{"outside point", -100, -100, 1, 1, 0, 0, 50, 50, true},
+		{"steep", 50, 25, 1e-6, 1, 50 - 25e-6, 0, 50 + 25e-6, 50, true},
+		{"miss", 0, 60, 1, 0, 0, 0, 0, 0, false},
+		{"miss diagonal", 200, 0, 1, 1, 0, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		x0, y0, x1, y1, ok := clipLine(tt.x, tt.y, tt.dx, tt.dy, r)
+		if ok != tt.ok {
+			t.Errorf("%v: ok %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		got := []float64{x0, y0, x1, y1}
+		for i, want := range []float64{tt.x0, tt.y0, tt.x1, tt.y1} {
+			if math.Abs(got[i]-want) > 1e-9 {
+				t.Errorf("%v: segment %v, want %v", tt.name, got,
+					[]float64{tt.x0, tt.y0, tt.x1, tt.y1})
+				break
+			}
+		}
+	}
+}
+
+func TestSlopeLineSteep(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	ax.SetXLim(0, 1)
+	ax.SetYLim(0, 1)
+	l, err := ax.SlopeLine(0.5, 0.5, 1e12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := image.NewRGBA(fig.Bounds())
+	l.Render(dst)
+	b := ax.Bounds()
+	x, _ := pixel(l, 0.5, 0.5)
+	for _, y := range []int{b.Min.Y + 1, (b.Min.Y + b.Max.Y) / 2, b.Max.Y - 1} {
+		if _, _, _, a := dst.At(int(x), y).RGBA(); a == 0 {
+			t.Errorf("Pixel (%v, %v) of the line not drawn", int(x), y)
+		}
+	}
+	if _, _, _, a := dst.At(int(x), b.Max.Y+5).RGBA(); a != 0 {
+		t.Error("Line drawn outside of the Axes")
+	}
+}
+
+func TestRefLineNotFinite(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	inf, nan := math.Inf(1), math.NaN()
+
+	for _, y := range []float64{inf, -inf, nan} {
+		if _, err := ax.HLine(y); err == nil {
+			t.Errorf("HLine(%v) accepted", y)
+		}
+		if _, err := ax.VLine(y); err == nil {
+			t.Errorf("VLine(%v) accepted", y)
+		}
+	}
+	for _, p := range [][3]float64{{inf, 0, 1}, {0, -inf, 1}, {0, 0, inf}, {0, 0, -inf}, {0, 0, nan}} {
+		if _, err := ax.SlopeLine(p[0], p[1], p[2]); err == nil {
+			t.Errorf("SlopeLine(%v, %v, %v) accepted", p[0], p[1], p[2])
+		}
+	}
+	for _, s := range [][2]float64{{0.5, inf}, {-inf, 0.5}, {-inf, inf}, {nan, 1}} {
+		if _, err := ax.HSpan(s[0], s[1]); err == nil {
+			t.Errorf("HSpan(%v, %v) accepted", s[0], s[1])
+		}
+		if _, err := ax.VSpan(s[0], s[1]); err == nil {
+			t.Errorf("VSpan(%v, %v) accepted", s[0], s[1])
+		}
+	}
+	if len(ax.children) != 0 {
+		t.Errorf("%v lines attached", len(ax.children))
+	}
+	if ax.XLim != [2]float64{0, 1} || ax.YLim != [2]float64{0, 1} {
+		t.Errorf("Limits changed to %v %v", ax.XLim, ax.YLim)
+	}
+
+	if l, err := ax.VLine(1); err != nil || !math.IsInf(l.Slope, 1) {
+		t.Errorf("VLine(1) = %v, %v", l, err)
+	}
+}
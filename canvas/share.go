@@ -0,0 +1,189 @@
+package canvas
+
+import (
+	"math"
+)
+
+// GridOption sets an optional feature of a GridSpec.
+// GridOptions are passed to SubAxes, NewGridSpec and SubGridSpec,
+// for example:
+//  axes, err := fig.SubAxes(3, 1, canvas.ShareX())
+type GridOption func(*GridSpec)
+
+// ShareX links the X limits of every Axes of the GridSpec.
+// Only the outer Axes show their X tick labels.
+func ShareX() GridOption {
+	return func(gs *GridSpec) {
+		gs.sharex = true
+	}
+}
+
+// ShareY links the Y limits of every Axes of the GridSpec.
+// Only the outer Axes show their Y tick labels.
+func ShareY() GridOption {
+	return func(gs *GridSpec) {
+		gs.sharey = true
+	}
+}
+
+// shareGroup holds the Axes that share the limits of a dimension.
+type shareGroup struct {
+	axes []*Axes
+}
+
+// merge returns a new group holding the Axes of a and b.
+func merge(a, b []*Axes) *shareGroup {
+	g := &shareGroup{}
+	seen := map[*Axes]bool{}
+	for _, ax := range append(append([]*Axes{}, a...), b...) {
+		if !seen[ax] {
+			seen[ax] = true
+			g.axes = append(g.axes, ax)
+		}
+	}
+	return g
+}
+
+// ShareX links the X limits of the Axes with other, so both Axes
+// autoscale to their combined data and fixing the limits of one
+// fixes the limits of the other.
+func (ax *Axes) ShareX(other *Axes) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.shareX(other)
+}
+
+// shareX links the X limits of ax and other.
+func (ax *Axes) shareX(other *Axes) {
+	g := merge(other.xgroup(), ax.xgroup())
+	for _, a := range g.axes {
+		a.sharex = g
+	}
+	// The linked Axes keep the first fixed limits found, if any.
+	for _, a := range g.axes {
+		if a.xfixed {
+			a.setXLim(a.XLim[0], a.XLim[1])
+			break
+		}
+	}
+	other.autoscale()
+	for _, a := range g.axes {
+		a.update()
+	}
+}
+
+// ShareY links the Y limits of the Axes with other, so both Axes
+// autoscale to their combined data and fixing the limits of one
+// fixes the limits of the other.
+func (ax *Axes) ShareY(other *Axes) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	ax.shareY(other)
+}
+
+// shareY links the Y limits of ax and other.
+func (ax *Axes) shareY(other *Axes) {
+	g := merge(other.ygroup(), ax.ygroup())
+	for _, a := range g.axes {
+		a.sharey = g
+	}
+	// The linked Axes keep the first fixed limits found, if any.
+	for _, a := range g.axes {
+		if a.yfixed {
+			a.setYLim(a.YLim[0], a.YLim[1])
+			break
+		}
+	}
+	other.autoscale()
+	for _, a := range g.axes {
+		a.update()
+	}
+}
+
+// xgroup returns the Axes sharing the X limits with ax, including itself.
+func (ax *Axes) xgroup() []*Axes {
+	if ax.sharex == nil {
+		return []*Axes{ax}
+	}
+	return ax.sharex.axes
+}
+
+// ygroup returns the Axes sharing the Y limits with ax, including itself.
+func (ax *Axes) ygroup() []*Axes {
+	if ax.sharey == nil {
+		return []*Axes{ax}
+	}
+	return ax.sharey.axes
+}
+
+// autoscale recalculates the data limits of the Axes, and of the Axes
+// linked to it, from their combined data range.
+func (ax *Axes) autoscale() {
+	if !ax.xfixed {
+		xdata := [2]float64{math.Inf(1), math.Inf(-1)}
+		for _, a := range ax.xgroup() {
+			xdata = [2]float64{math.Min(xdata[0], a.xdata[0]), math.Max(xdata[1], a.xdata[1])}
+		}
+		xlim := margin(xdata, 0.05)
+		for _, a := range ax.xgroup() {
+			a.XLim = xlim
+			if a != ax {
+				a.update()
+			}
+		}
+	}
+	if !ax.yfixed {
+		ydata := [2]float64{math.Inf(1), math.Inf(-1)}
+		for _, a := range ax.ygroup() {
+			ydata = [2]float64{math.Min(ydata[0], a.ydata[0]), math.Max(ydata[1], a.ydata[1])}
+		}
+		ylim := margin(ydata, 0.05)
+		for _, a := range ax.ygroup() {
+			a.YLim = ylim
+			if a != ax {
+				a.update()
+			}
+		}
+	}
+	ax.update()
+}
+
+// inner reports whether the tick labels of the Axis at loc are hidden
+// because another Axes of the same GridSpec, sharing that dimension,
+// lies next to that side.
+func (ax *Axes) inner(loc Alignment) bool {
+	group := ax.ygroup()
+	if loc == BottomAxis || loc == TopAxis {
+		group = ax.xgroup()
+	}
+	if ax.spec == nil || len(group) < 2 {
+		return false
+	}
+
+	s := ax.span
+	for _, a := range group {
+		if a == ax || a.spec != ax.spec {
+			continue
+		}
+		o := a.span
+		switch loc {
+		case BottomAxis:
+			if o[0] >= s[1] {
+				return true
+			}
+		case TopAxis:
+			if o[1] <= s[0] {
+				return true
+			}
+		case LeftAxis:
+			if o[3] <= s[2] {
+				return true
+			}
+		case RightAxis:
+			if o[2] >= s[3] {
+				return true
+			}
+		}
+	}
+	return false
+}
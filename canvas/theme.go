@@ -0,0 +1,487 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/colornames"
+)
+
+// Theme defines the default look of a Figure and all its elements.
+// A Theme is applied per Figure with SetTheme.
+type Theme struct {
+	Name string
+	// FigureColor and AxesColor are the background colors of the Figure
+	// and of each Axes.
+	FigureColor, AxesColor color.Color
+	// SpineColor is the color of the border of each Axes and SpineWidth
+	// its width in points. A SpineWidth of 0 draws no border.
+	SpineColor color.Color
+	SpineWidth int
+	// TextColor is the color of tick labels, texts, legends and
+	// annotations, and TickColor the color of the ticks.
+	TextColor, TickColor color.Color
+	// EdgeColor is the color of error bars and zoom indicators.
+	EdgeColor color.Color
+	// LegendColor and LegendEdge are the background and border colors
+	// of legends.
+	LegendColor, LegendEdge color.Color
+	// UpColor and DownColor are the colors of the rising and falling
+	// periods of financial charts.
+	UpColor, DownColor color.Color
+	// Font is the typeface of every text and FontScale resizes the
+	// tick labels, texts and legends.
+	// FontSize is the size in points of every text. A FontSize of 0
+	// sizes each text relative to its Axes instead.
+	Font      *truetype.Font
+	FontScale float64
+	FontSize  float64
+	// Grid and MinorGrid are the default grid lines of each Axis.
+	Grid, MinorGrid GridStyle
+	// Palette holds the colors assigned in turn to the plots of an Axes
+	// that do not define their own color.
+	Palette []color.Color
+	// LineWidth is the default width in points of line plots and
+	// MarkerSize the default size in points of scatter markers.
+	LineWidth  int
+	MarkerSize float64
+}
+
+// DefaultTheme returns the default Theme of a Figure.
+func DefaultTheme() *Theme {
+	return &Theme{
+		Name:        "default",
+		FigureColor: colornames.Gainsboro,
+		AxesColor:   colornames.White,
+		SpineColor:  colornames.Black,
+		SpineWidth:  2,
+		TextColor:   colornames.Black,
+		TickColor:   colornames.Black,
+		EdgeColor:   colornames.Black,
+		LegendColor: color.NRGBA{0xff, 0xff, 0xff, 0xcc},
+		LegendEdge:  colornames.Darkgray,
+		UpColor:     colornames.Seagreen,
+		DownColor:   colornames.Crimson,
+		Font:        defaultFont(),
+		FontScale:   1,
+		Grid:        GridStyle{Color: colornames.Lightgray, Width: 1, Style: Solid},
+		MinorGrid:   GridStyle{Color: colornames.Whitesmoke, Width: 1, Style: Dotted},
+		Palette:     append([]color.Color{}, Palette...),
+		LineWidth:   2,
+		MarkerSize:  6,
+	}
+}
+
+// DarkTheme returns a Theme with light elements over a dark background.
+// Its Palette uses light, desaturated colors that keep their contrast
+// against the dark Axes.
+func DarkTheme() *Theme {
+	t := DefaultTheme()
+	t.Name = "dark"
+	t.FigureColor = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+	t.AxesColor = color.RGBA{0x2b, 0x2b, 0x2b, 0xff}
+	t.SpineColor = color.RGBA{0x9e, 0x9e, 0x9e, 0xff}
+	t.SpineWidth = 1
+	t.TextColor = color.RGBA{0xdc, 0xdc, 0xdc, 0xff}
+	t.TickColor = color.RGBA{0x9e, 0x9e, 0x9e, 0xff}
+	t.EdgeColor = color.RGBA{0xdc, 0xdc, 0xdc, 0xff}
+	t.LegendColor = color.NRGBA{0x2b, 0x2b, 0x2b, 0xdd}
+	t.LegendEdge = color.RGBA{0x5a, 0x5a, 0x5a, 0xff}
+	t.UpColor = color.RGBA{0x26, 0xa6, 0x9a, 0xff}
+	t.DownColor = color.RGBA{0xef, 0x53, 0x50, 0xff}
+	t.Grid.Color = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	t.MinorGrid.Color = color.RGBA{0x36, 0x36, 0x36, 0xff}
+	t.Palette = []color.Color{
+		color.RGBA{0x8d, 0xd3, 0xc7, 0xff},
+		color.RGBA{0xfe, 0xff, 0xb3, 0xff},
+		color.RGBA{0xbf, 0xbb, 0xd9, 0xff},
+		color.RGBA{0xfa, 0x81, 0x74, 0xff},
+		color.RGBA{0x81, 0xb1, 0xd2, 0xff},
+		color.RGBA{0xfd, 0xb4, 0x62, 0xff},
+		color.RGBA{0xb3, 0xde, 0x69, 0xff},
+		color.RGBA{0xbc, 0x82, 0xbd, 0xff},
+		color.RGBA{0xcc, 0xeb, 0xc4, 0xff},
+		color.RGBA{0xff, 0xed, 0x6f, 0xff},
+	}
+	return t
+}
+
+// GGPlotTheme returns a Theme similar to the default look of ggplot2:
+// gray Axes with a white grid and no border.
+func GGPlotTheme() *Theme {
+	t := DefaultTheme()
+	t.Name = "ggplot"
+	t.FigureColor = colornames.White
+	t.AxesColor = color.RGBA{0xeb, 0xeb, 0xeb, 0xff}
+	t.SpineWidth = 0
+	t.Grid = GridStyle{Show: true, Color: colornames.White, Width: 2, Style: Solid}
+	t.MinorGrid = GridStyle{Color: color.RGBA{0xf5, 0xf5, 0xf5, 0xff}, Width: 1, Style: Solid}
+	t.Palette = []color.Color{
+		color.RGBA{0xe2, 0x4a, 0x33, 0xff},
+		color.RGBA{0x34, 0x8a, 0xbd, 0xff},
+		color.RGBA{0x98, 0x8e, 0xd5, 0xff},
+		color.RGBA{0x77, 0x77, 0x77, 0xff},
+		color.RGBA{0xfb, 0xc1, 0x5e, 0xff},
+		color.RGBA{0x8e, 0xba, 0x42, 0xff},
+		color.RGBA{0xff, 0xb5, 0xb8, 0xff},
+	}
+	return t
+}
+
+// MinimalTheme returns a Theme with a white background, a thin border
+// and a light grid.
+func MinimalTheme() *Theme {
+	t := DefaultTheme()
+	t.Name = "minimal"
+	t.FigureColor = colornames.White
+	t.SpineColor = colornames.Lightgray
+	t.SpineWidth = 1
+	t.Grid = GridStyle{Show: true, Color: colornames.Whitesmoke, Width: 1, Style: Solid}
+	return t
+}
+
+// PrintTheme returns a monochrome Theme for printing, where plots are
+// told apart by their shade of gray.
+func PrintTheme() *Theme {
+	t := DefaultTheme()
+	t.Name = "print"
+	t.FigureColor = colornames.White
+	t.Grid = GridStyle{Color: colornames.Darkgray, Width: 1, Style: Dotted}
+	t.MinorGrid = GridStyle{Color: colornames.Lightgray, Width: 1, Style: Dotted}
+	t.UpColor = color.Gray{0xd0}
+	t.DownColor = colornames.Black
+	t.Palette = []color.Color{
+		color.Gray{0x00},
+		color.Gray{0x60},
+		color.Gray{0x98},
+		color.Gray{0x30},
+		color.Gray{0x80},
+		color.Gray{0xb8},
+	}
+	return t
+}
+
+// themes holds the built-in Themes by name.
+var themes = map[string]func() *Theme{
+	"default": DefaultTheme,
+	"dark":    DarkTheme,
+	"ggplot":  GGPlotTheme,
+	"minimal": MinimalTheme,
+	"print":   PrintTheme,
+}
+
+// color returns the i-th color of the Palette of the Theme.
+func (t *Theme) color(i int) color.Color {
+	if len(t.Palette) == 0 {
+		return Palette[i%len(Palette)]
+	}
+	return t.Palette[i%len(t.Palette)]
+}
+
+// newFont returns the font of the Theme of the Figure drawn with TextColor.
+// The text is FontSize points tall or, if the Theme has no FontSize,
+// height pixels tall at the DPI of the Figure.
+func (f *Figure) newFont(height int) *fontType {
+	t := f.theme
+	if t.FontSize > 0 {
+		ft, _ := newFont(t.Font, t.FontSize*t.FontScale, f.DPI, t.TextColor)
+		return ft
+	}
+	size := float64(int(float64(height)*t.FontScale)) * 72 / f.DPI
+	ft, _ := newFont(t.Font, size, f.DPI, t.TextColor)
+	return ft
+}
+
+// gridJSON is the JSON representation of a GridStyle.
+type gridJSON struct {
+	Show  *bool  `json:"show"`
+	Color string `json:"color"`
+	Width int    `json:"width"`
+	Style string `json:"style"`
+}
+
+// themeJSON is the JSON representation of a Theme.
+// Missing fields keep the value of the Theme named by Base.
+type themeJSON struct {
+	Name        string    `json:"name"`
+	Base        string    `json:"base"`
+	FigureColor string    `json:"figure_color"`
+	AxesColor   string    `json:"axes_color"`
+	SpineColor  string    `json:"spine_color"`
+	SpineWidth  *int      `json:"spine_width"`
+	TextColor   string    `json:"text_color"`
+	TickColor   string    `json:"tick_color"`
+	EdgeColor   string    `json:"edge_color"`
+	LegendColor string    `json:"legend_color"`
+	LegendEdge  string    `json:"legend_edge"`
+	UpColor     string    `json:"up_color"`
+	DownColor   string    `json:"down_color"`
+	Font        string    `json:"font"`
+	FontScale   float64   `json:"font_scale"`
+	FontSize    float64   `json:"font_size"`
+	Grid        *gridJSON `json:"grid"`
+	MinorGrid   *gridJSON `json:"minor_grid"`
+	Palette     []string  `json:"palette"`
+	LineWidth   int       `json:"line_width"`
+	MarkerSize  float64   `json:"marker_size"`
+}
+
+// LoadTheme reads a Theme from a JSON file, see ParseTheme.
+func LoadTheme(file string) (*Theme, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTheme(data)
+}
+
+// ParseTheme reads a Theme from JSON data such as:
+//  {
+//    "name": "solarized",
+//    "base": "minimal",
+//    "figure_color": "#fdf6e3",
+//    "axes_color": "#eee8d5",
+//    "grid": {"show": true, "color": "#93a1a1", "style": "dashed"},
+//    "palette": ["#268bd2", "#dc322f", "#859900"]
+//  }
+// The fields not set are taken from the built-in Theme named by base,
+// or from DefaultTheme, and unknown fields are an error. Colors are written as hexadecimal "#rrggbb",
+// "#rrggbbaa", "#rgb" or by their SVG name, and "font" is the path of
+// a TrueType file.
+func ParseTheme(data []byte) (*Theme, error) {
+	var j themeJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return nil, err
+	}
+
+	t := DefaultTheme()
+	if j.Base != "" {
+		base, ok := themes[j.Base]
+		if !ok {
+			return nil, fmt.Errorf("Theme %q not found", j.Base)
+		}
+		t = base()
+	}
+	if j.Name != "" {
+		t.Name = j.Name
+	}
+
+	colors := []struct {
+		text string
+		dst  *color.Color
+	}{
+		{j.FigureColor, &t.FigureColor},
+		{j.AxesColor, &t.AxesColor},
+		{j.SpineColor, &t.SpineColor},
+		{j.TextColor, &t.TextColor},
+		{j.TickColor, &t.TickColor},
+		{j.EdgeColor, &t.EdgeColor},
+		{j.LegendColor, &t.LegendColor},
+		{j.LegendEdge, &t.LegendEdge},
+		{j.UpColor, &t.UpColor},
+		{j.DownColor, &t.DownColor},
+	}
+	for _, c := range colors {
+		if c.text == "" {
+			continue
+		}
+		v, err := parseColor(c.text)
+		if err != nil {
+			return nil, err
+		}
+		*c.dst = v
+	}
+
+	if j.SpineWidth != nil {
+		t.SpineWidth = *j.SpineWidth
+	}
+	if j.Font != "" {
+		file, err := ioutil.ReadFile(j.Font)
+		if err != nil {
+			return nil, err
+		}
+		ttf, err := truetype.Parse(file)
+		if err != nil {
+			return nil, err
+		}
+		t.Font = ttf
+	}
+	if j.FontScale > 0 {
+		t.FontScale = j.FontScale
+	}
+	if j.FontSize > 0 {
+		t.FontSize = j.FontSize
+	}
+	for _, g := range []struct {
+		j   *gridJSON
+		dst *GridStyle
+	}{{j.Grid, &t.Grid}, {j.MinorGrid, &t.MinorGrid}} {
+		if g.j == nil {
+			continue
+		}
+		if err := g.j.apply(g.dst); err != nil {
+			return nil, err
+		}
+	}
+	if j.Palette != nil {
+		t.Palette = nil
+		for _, text := range j.Palette {
+			c, err := parseColor(text)
+			if err != nil {
+				return nil, err
+			}
+			t.Palette = append(t.Palette, c)
+		}
+	}
+	if j.LineWidth > 0 {
+		t.LineWidth = j.LineWidth
+	}
+	if j.MarkerSize > 0 {
+		t.MarkerSize = j.MarkerSize
+	}
+
+	return t, nil
+}
+
+// apply sets the fields of g found in the JSON representation.
+func (j *gridJSON) apply(g *GridStyle) error {
+	if j.Show != nil {
+		g.Show = *j.Show
+	}
+	if j.Color != "" {
+		c, err := parseColor(j.Color)
+		if err != nil {
+			return err
+		}
+		g.Color = c
+	}
+	if j.Width > 0 {
+		g.Width = j.Width
+	}
+	switch j.Style {
+	case "":
+	case "solid":
+		g.Style = Solid
+	case "dashed":
+		g.Style = Dashed
+	case "dotted":
+		g.Style = Dotted
+	case "dashdot":
+		g.Style = DashDot
+	default:
+		return fmt.Errorf("Line style %q not valid", j.Style)
+	}
+	return nil
+}
+
+// parseColor returns the color written as hexadecimal "#rrggbb",
+// "#rrggbbaa", "#rgb" or by its SVG name.
+func parseColor(text string) (color.Color, error) {
+	if !strings.HasPrefix(text, "#") {
+		c, ok := colornames.Map[strings.ToLower(text)]
+		if !ok {
+			return nil, fmt.Errorf("Color %q not valid", text)
+		}
+		return c, nil
+	}
+
+	hex := text[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return nil, fmt.Errorf("Color %q not valid", text)
+	}
+	return color.NRGBA{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}, nil
+}
+
+// SetTheme applies the Theme t to the Figure.
+// The backgrounds, borders, grid lines, ticks, texts, legends, error bars,
+// candles and zoom indicators of the elements already attached,
+// PolarAxes included, are restyled, unless their color was set with an
+// option. Plots keep their colors, so the Theme should be set before
+// plotting.
+func (f *Figure) SetTheme(t *Theme) {
+	f.Lock()
+	defer f.Unlock()
+	old := f.theme
+	f.theme = t
+	f.FillColor = t.FigureColor
+
+	var walk func(c Container)
+	walk = func(c Container) {
+		switch e := c.(type) {
+		case *Axes:
+			if e.host == nil {
+				e.FillColor = t.AxesColor
+			}
+		case *Axis:
+			e.Grid, e.MinorGrid = t.Grid, t.MinorGrid
+		case *Label:
+			e.FillColor = t.TextColor
+		case *Tick:
+			e.FillColor = t.TickColor
+		case *Legend:
+			e.FillColor, e.StrokeColor = t.LegendColor, t.LegendEdge
+		case *Text:
+			e.FillColor = restyle(e.FillColor, old.TextColor, t.TextColor)
+		case *Annotation:
+			e.FillColor = restyle(e.FillColor, old.TextColor, t.TextColor)
+		case *ErrorBar:
+			e.FillColor = restyle(e.FillColor, old.EdgeColor, t.EdgeColor)
+		case *ZoomIndicator:
+			e.StrokeColor = restyle(e.StrokeColor, old.EdgeColor, t.EdgeColor)
+		case *Candle:
+			if c := restyle(e.FillColor, old.UpColor, t.UpColor); c != e.FillColor {
+				e.FillColor = c
+			} else {
+				e.FillColor = restyle(e.FillColor, old.DownColor, t.DownColor)
+			}
+		case *PolarAxes:
+			// The background and grids are made again with the Theme.
+			e.layout()
+		}
+		for _, child := range c.Children() {
+			walk(child)
+		}
+	}
+	for _, child := range f.children {
+		walk(child)
+	}
+}
+
+// restyle returns to if c is the color from of the previous Theme, with
+// the opacity given to c by the option Alpha. Any other color is kept.
+func restyle(c, from, to color.Color) color.Color {
+	if c == nil || from == nil || to == nil {
+		return c
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	m := color.NRGBAModel.Convert(from).(color.NRGBA)
+	if n.R != m.R || n.G != m.G || n.B != m.B {
+		return c
+	}
+	if n.A == m.A || m.A == 0 {
+		return to
+	}
+	return withAlpha(to, float64(n.A)/float64(m.A))
+}
+
+// Theme returns the Theme applied to the Figure.
+func (f *Figure) Theme() *Theme {
+	f.Lock()
+	defer f.Unlock()
+	return f.theme
+}
@@ -0,0 +1,233 @@
+package canvas
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/image/colornames"
+)
+
+func TestParseTheme(t *testing.T) {
+	th, err := ParseTheme([]byte(`{
+		"name": "solarized",
+		"base": "minimal",
+		"figure_color": "#fdf6e3",
+		"axes_color": "#eee8d580",
+		"text_color": "navy",
+		"spine_width": 0,
+		"grid": {"show": true, "color": "#abc", "style": "dashed"},
+		"palette": ["#268bd2", "red"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := MinimalTheme()
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"name", th.Name, "solarized"},
+		{"figure_color", th.FigureColor, color.NRGBA{0xfd, 0xf6, 0xe3, 0xff}},
+		{"axes_color", th.AxesColor, color.NRGBA{0xee, 0xe8, 0xd5, 0x80}},
+		{"text_color", th.TextColor, colornames.Navy},
+		{"spine_width", th.SpineWidth, 0},
+		{"grid show", th.Grid.Show, true},
+		{"grid color", th.Grid.Color, color.NRGBA{0xaa, 0xbb, 0xcc, 0xff}},
+		{"grid style", th.Grid.Style, Dashed},
+		{"grid width", th.Grid.Width, base.Grid.Width},
+		{"palette", len(th.Palette), 2},
+		{"palette red", th.Palette[1], colornames.Red},
+		{"tick_color from base", th.TickColor, base.TickColor},
+		{"line_width from base", th.LineWidth, base.LineWidth},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%v: %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParseThemeErrors(t *testing.T) {
+	tests := []struct {
+		name, data, err string
+	}{
+		{"bad key", `{"figure_colour": "#fff"}`, "figure_colour"},
+		{"bad grid key", `{"grid": {"colour": "#fff"}}`, "colour"},
+		{"bad base", `{"base": "neon"}`, "neon"},
+		{"bad color", `{"axes_color": "#12345"}`, "#12345"},
+		{"bad color name", `{"palette": ["blurple"]}`, "blurple"},
+		{"bad style", `{"grid": {"style": "wavy"}}`, "wavy"},
+		{"bad type", `{"font_size": "large"}`, "font_size"},
+		{"bad JSON", `{"name": `, "EOF"},
+	}
+	for _, tt := range tests {
+		th, err := ParseTheme([]byte(tt.data))
+		if err == nil {
+			t.Errorf("%v: parsed %v", tt.name, th.Name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%v: error %q does not mention %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestSetThemePolar(t *testing.T) {
+	fig, err := NewFigure(400, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pa := fig.NewPolarAxes()
+	c := color.RGBA{1, 2, 3, 255}
+	if err := pa.LinePlot([]float64{0, 1, 2}, []float64{1, 2, 1}, Color(c)); err != nil {
+		t.Fatal(err)
+	}
+	dark := DarkTheme()
+	fig.SetTheme(dark)
+
+	var bg *Polygon
+	var grid, plot int
+	for _, child := range pa.Children() {
+		switch e := child.(type) {
+		case *Polygon:
+			if bg == nil {
+				bg = e
+			}
+		case *Line:
+			switch e.FillColor {
+			case dark.Grid.Color:
+				grid++
+			case c:
+				plot++
+			}
+		}
+	}
+	if bg == nil || bg.FillColor != dark.AxesColor {
+		t.Errorf("Background %v, want the color %v of the Theme", bg, dark.AxesColor)
+	}
+	if grid == 0 {
+		t.Error("No grid line with the color of the Theme")
+	}
+	if plot != 1 {
+		t.Errorf("%v lines keep the color of the plot, want 1", plot)
+	}
+}
+
+func TestPolygonThemeColor(t *testing.T) {
+	fig, err := NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th := DefaultTheme()
+	th.Palette = []color.Color{colornames.Teal}
+	fig.SetTheme(th)
+	p, err := newPolygon(fig.NewAxes(), []float64{0, 1, 1}, []float64{0, 0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.FillColor != colornames.Teal {
+		t.Errorf("Polygon filled with %v, want the first color of the Theme", p.FillColor)
+	}
+}
+
+func TestSetThemeRestyle(t *testing.T) {
+	fig, err := NewFigure(600, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	axs, err := fig.SubAxes(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := axs[0].Pie([]float64{2, 1}, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	X, Y := []float64{0, 1, 2}, []float64{1, 2, 1}
+	if err := axs[1].ErrorBar(X, Y, []float64{0.1, 0.1, 0.1}); err != nil {
+		t.Fatal(err)
+	}
+	note, _ := axs[1].Annotate("note", [2]float64{1, 2}, [2]float64{1.5, 1.5})
+	red := color.RGBA{255, 0, 0, 255}
+	kept, _ := axs[1].Annotate("kept", [2]float64{1, 2}, [2]float64{0.5, 1.5}, Color(red))
+	in, err := axs[1].Inset([4]float64{0.6, 0.6, 0.3, 0.3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zoom, _ := axs[1].IndicateZoom(in)
+
+	T := []time.Time{time.Unix(0, 0), time.Unix(86400, 0)}
+	if err := axs[2].Candlestick(T, []float64{1, 3}, []float64{4, 4}, []float64{0, 0}, []float64{3, 1}, Alpha(0.5)); err != nil {
+		t.Fatal(err)
+	}
+
+	dark := DarkTheme()
+	fig.SetTheme(dark)
+
+	var texts, bars, candles int
+	for _, ax := range axs {
+		for _, c := range ax.children {
+			switch e := c.(type) {
+			case *Text:
+				texts++
+				if e.FillColor != dark.TextColor {
+					t.Errorf("Text %q colored %v, want %v", e.Text, e.FillColor, dark.TextColor)
+				}
+			case *ErrorBar:
+				bars++
+				if e.FillColor != dark.EdgeColor {
+					t.Errorf("ErrorBar colored %v, want %v", e.FillColor, dark.EdgeColor)
+				}
+			case *Candle:
+				want := withAlpha(dark.UpColor, 0.5)
+				if e.Close < e.Open {
+					want = withAlpha(dark.DownColor, 0.5)
+				}
+				candles++
+				if e.FillColor != want {
+					t.Errorf("Candle %v colored %v, want %v", candles, e.FillColor, want)
+				}
+			}
+		}
+	}
+	if texts == 0 || bars != 3 || candles != 2 {
+		t.Errorf("%v texts, %v error bars and %v candles restyled", texts, bars, candles)
+	}
+	if note.FillColor != dark.TextColor {
+		t.Errorf("Annotation colored %v, want %v", note.FillColor, dark.TextColor)
+	}
+	if kept.FillColor != red {
+		t.Errorf("Annotation with the option Color recolored to %v", kept.FillColor)
+	}
+	if zoom.StrokeColor != dark.EdgeColor {
+		t.Errorf("ZoomIndicator colored %v, want %v", zoom.StrokeColor, dark.EdgeColor)
+	}
+}
+
+func TestFontDPI(t *testing.T) {
+	fig, err := NewFigureInches(4, 3, 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	txt, err := newText(ax, 0.5, 0.5, "Proportions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := txt.Extent()
+	if err := fig.SetDPI(144); err != nil {
+		t.Fatal(err)
+	}
+	after := txt.Extent()
+
+	// Glyph advances are rounded to whole pixels at each size.
+	got := []int{after.Min.X, after.Min.Y, after.Dx(), after.Dy()}
+	for i, v := range []int{before.Min.X, before.Min.Y, before.Dx(), before.Dy()} {
+		if d := math.Abs(float64(got[i]-2*v)); d > math.Max(2, 0.05*float64(2*v)) {
+			t.Fatalf("Text covers %v at 144 DPI, want twice %v at 72 DPI", after, before)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package canvas
+
+// Affine2D is an affine transformation of the plane given by the matrix
+//  | A C E |
+//  | B D F |
+//  | 0 0 1 |
+// It is a small value type, so it is copied and combined without
+// allocations.
+type Affine2D struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the Affine2D that leaves every point in place.
+func Identity() Affine2D {
+	return Affine2D{A: 1, D: 1}
+}
+
+// ScaleTranslate returns the Affine2D that scales a point by (sx, sy)
+// and then moves it by (tx, ty).
+func ScaleTranslate(sx, sy, tx, ty float64) Affine2D {
+	return Affine2D{A: sx, D: sy, E: tx, F: ty}
+}
+
+// Mul returns the Affine2D that applies b and then a.
+func (a Affine2D) Mul(b Affine2D) Affine2D {
+	return Affine2D{
+		A: a.A*b.A + a.C*b.B,
+		B: a.B*b.A + a.D*b.B,
+		C: a.A*b.C + a.C*b.D,
+		D: a.B*b.C + a.D*b.D,
+		E: a.A*b.E + a.C*b.F + a.E,
+		F: a.B*b.E + a.D*b.F + a.F,
+	}
+}
+
+// Apply returns the point (x, y) transformed by a.
+func (a Affine2D) Apply(x, y float64) (float64, float64) {
+	return a.A*x + a.C*y + a.E, a.B*x + a.D*y + a.F
+}
+
+// transform is a node of the tree of transformations of a Figure.
+// It maps its coordinates into those of its parent with local and
+// caches the full transformation into pixels.
+//
+// Every element refers to the node of the coordinates it is located in,
+// which is usually shared with its siblings, so the cache is computed
+// once for all of them.
+type transform struct {
+	parent *transform
+	local  Affine2D
+
+	full  Affine2D
+	valid bool
+	// rev counts the changes of full, and prev is the rev of the parent
+	// full was computed from, so a change of any ancestor invalidates
+	// the cache of its descendants.
+	rev, prev uint64
+}
+
+// newTransform creates a node that maps its coordinates into those of
+// parent with local. A nil parent maps them directly into pixels.
+func newTransform(parent *transform, local Affine2D) *transform {
+	return &transform{parent: parent, local: local}
+}
+
+// set changes the local transformation of the node.
+func (t *transform) set(local Affine2D) {
+	t.local = local
+	t.valid = false
+}
+
+// setParent moves the node under parent.
+func (t *transform) setParent(parent *transform) {
+	t.parent = parent
+	t.valid = false
+}
+
+// pixels returns the transformation of the node into pixels,
+// updating the cache if the node or any of its ancestors changed.
+func (t *transform) pixels() Affine2D {
+	if t.parent == nil {
+		if !t.valid {
+			t.full = t.local
+			t.valid = true
+			t.rev++
+		}
+		return t.full
+	}
+
+	p := t.parent.pixels()
+	if !t.valid || t.prev != t.parent.rev {
+		t.full = p.Mul(t.local)
+		t.prev = t.parent.rev
+		t.valid = true
+		t.rev++
+	}
+	return t.full
+}
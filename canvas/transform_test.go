@@ -0,0 +1,119 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// scatterAxes returns an Axes of a Figure with a scatter of n points.
+func scatterAxes(b *testing.B, n int) *Axes {
+	fig, err := NewFigure(800, 600)
+	if err != nil {
+		b.Fatal(err)
+	}
+	ax := fig.NewAxes()
+	X := make([]float64, n)
+	Y := make([]float64, n)
+	for i := range X {
+		X[i] = float64(i)
+		Y[i] = math.Sin(float64(i) / 100)
+	}
+	if err := ax.ScatterPlot(X, Y); err != nil {
+		b.Fatal(err)
+	}
+	return ax
+}
+
+// firstPoint returns the first ScatterPoint of the Axes.
+func firstPoint(ax *Axes) *ScatterPoint {
+	for _, c := range ax.children {
+		if p, ok := c.(*ScatterPoint); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// denseChain returns the transformations from t up to the root as the
+// chain of gonum matrices used before Affine2D.
+func denseChain(t *transform) []*mat.Dense {
+	var T []*mat.Dense
+	for ; t != nil; t = t.parent {
+		a := t.local
+		m := mat.NewDense(3, 3, []float64{
+			a.A, a.C, a.E,
+			a.B, a.D, a.F,
+			0, 0, 1,
+		})
+		T = append([]*mat.Dense{m}, T...)
+	}
+	// The last matrix belonged to the children of the element.
+	return append(T, nil)
+}
+
+// densePixel transforms (x, y) into pixels multiplying the chain T
+// the way every element did before Affine2D.
+func densePixel(T []*mat.Dense, x, y float64) (float64, float64) {
+	trans := mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1})
+	for _, m := range T[:len(T)-1] {
+		trans.Product(trans, m)
+	}
+	var v mat.VecDense
+	v.MulVec(trans, mat.NewVecDense(3, []float64{x, y, 1}))
+	return v.AtVec(0), v.AtVec(1)
+}
+
+func BenchmarkPixelAffine2D(b *testing.B) {
+	p := firstPoint(scatterAxes(b, 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pixel(p, float64(i), 0.5)
+	}
+}
+
+func BenchmarkPixelDense(b *testing.B) {
+	p := firstPoint(scatterAxes(b, 1))
+	T := denseChain(p.t)
+	x0, y0 := pixel(p, 3, 0.5)
+	if x1, y1 := densePixel(T, 3, 0.5); x0 != x1 || y0 != y1 {
+		b.Fatalf("Dense (%v, %v) != Affine2D (%v, %v)", x1, y1, x0, y0)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		densePixel(T, float64(i), 0.5)
+	}
+}
+
+// The scatter benchmarks locate every marker of a scatter of 100k
+// points, as rendering does.
+
+func BenchmarkScatterBoundsAffine2D(b *testing.B) {
+	ax := scatterAxes(b, 100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range ax.children {
+			if p, ok := c.(*ScatterPoint); ok {
+				p.Bounds()
+			}
+		}
+	}
+}
+
+func BenchmarkScatterBoundsDense(b *testing.B) {
+	ax := scatterAxes(b, 100000)
+	T := denseChain(ax.dataT)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range ax.children {
+			if p, ok := c.(*ScatterPoint); ok {
+				densePixel(T, p.X, p.Y)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package canvas
+
+import (
+	"image/color"
+)
+
+// TwinX attaches a new Axes overlaying ax that shares its X limits.
+// The Y limits of the new Axes are independent and shown on the
+// right Axis.
+func (ax *Axes) TwinX() (*Axes, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.twin(RightAxis)
+}
+
+// TwinY attaches a new Axes overlaying ax that shares its Y limits.
+// The X limits of the new Axes are independent and shown on the
+// top Axis.
+func (ax *Axes) TwinY() (*Axes, error) {
+	ax.Parent.Lock()
+	defer ax.Parent.Unlock()
+	return ax.twin(TopAxis)
+}
+
+// twin creates the overlay Axes of ax that shows its own dimension
+// on the Axis at side.
+func (ax *Axes) twin(side Alignment) (*Axes, error) {
+	t, err := newAxes(ax.Parent, ax.Origin[0], ax.Origin[1], ax.Size[0], ax.Size[1])
+	if err != nil {
+		return nil, err
+	}
+	// The twin shares the placement of ax, so it follows any layout.
+	t.tc = ax.tc
+	t.dataT.setParent(t.tc)
+	t.FillColor = color.Transparent
+	t.host = ax
+	t.twinSide = side
+	ax.twins = append(ax.twins, t)
+
+	// The side of the twin is no longer available to ax.
+	if a := ax.axis[side]; a != nil {
+		ax.axis[side] = nil
+		for i, c := range ax.children {
+			if c == a {
+				ax.children = append(ax.children[:i], ax.children[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if side == RightAxis {
+		t.shareX(ax)
+	} else {
+		t.shareY(ax)
+	}
+
+	if l := ax.root().legend; l != nil {
+		ax.Parent.raise(l)
+	}
+
+	return t, nil
+}
+
+// root returns the Axes at the bottom of a stack of twins.
+func (ax *Axes) root() *Axes {
+	for ax.host != nil {
+		ax = ax.host
+	}
+	return ax
+}
+
+// side returns the Axis showing the dimension of loc for the plots
+// of the Axes, creating it if needed.
+// A twin Axes shows its own dimension on the side opposite to its host
+// and uses the Axis of its host for the shared dimension.
+// It returns nil if the side is taken by a twin.
+func (ax *Axes) side(loc Alignment) *Axis {
+	for _, t := range ax.twins {
+		if loc == t.twinSide {
+			return nil
+		}
+	}
+	if ax.host == nil {
+		return ax.axisAt(loc)
+	}
+
+	switch loc {
+	case ax.twinSide:
+		return nil
+	case (ax.twinSide + 2) % 4:
+		return ax.axisAt(ax.twinSide)
+	}
+	return ax.host.side(loc)
+}
+
+// raise moves c to the end of the children of the Figure,
+// so it is drawn on top of everything else.
+func (f *Figure) raise(c Container) {
+	for i, child := range f.children {
+		if child == c {
+			f.children = append(f.children[:i], f.children[i+1:]...)
+			break
+		}
+	}
+	f.children = append(f.children, c)
+}
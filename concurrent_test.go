@@ -0,0 +1,157 @@
+package plt
+
+import (
+	"bytes"
+	"image"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/cgxeiji/plt/canvas"
+)
+
+// The tests of this file are meant to run with the race detector:
+//  go test -race
+
+// TestConcurrentFigures renders a Figure per goroutine, as a web
+// handler does.
+func TestConcurrentFigures(t *testing.T) {
+	figs := make([]*canvas.Figure, 8)
+	for i := range figs {
+		figs[i] = dashboard(t)
+	}
+
+	var wg sync.WaitGroup
+	for i, f := range figs {
+		wg.Add(1)
+		go func(i int, f *canvas.Figure) {
+			defer wg.Done()
+			if i%2 == 0 {
+				Render(f)
+			} else {
+				RenderParallel(f)
+			}
+		}(i, f)
+	}
+	wg.Wait()
+}
+
+// TestConcurrentPlotRender plots into a shared Figure from a goroutine
+// while other goroutines render it.
+func TestConcurrentPlotRender(t *testing.T) {
+	f, err := canvas.NewFigure(800, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs, err := f.NewGridSpec(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var axs []*canvas.Axes
+	for i := 0; i < 4; i++ {
+		ax, err := gs.Axes(i/2, i/2+1, i%2, i%2+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		axs = append(axs, ax)
+	}
+	pa := f.NewPolarAxes()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if i%2 == 0 {
+					Render(f)
+				} else {
+					RenderParallel(f)
+				}
+			}
+		}(i)
+	}
+
+	for i := 0; i < 20; i++ {
+		X, Y := wave(50, float64(i))
+		ax := axs[i%4]
+		switch i % 5 {
+		case 0:
+			ax.LinePlot(X, Y, canvas.LegendLabel("line"))
+			ax.Legend()
+		case 1:
+			ax.ScatterPlot(X, Y)
+			ax.Grid(i%2 == 0)
+		case 2:
+			ax.Scatter(X, Y)
+			ax.SetXLim(0, float64(10+i))
+		case 3:
+			ax.HLine(0.5)
+			ax.Annotate("note", [2]float64{5, 0.5}, [2]float64{20, 0.8}, canvas.Arrow(canvas.ArrowSimple))
+		case 4:
+			pa.LinePlot(X, Y)
+			f.Resize(800+float64(i), 600)
+			f.TightLayout(4)
+		}
+
+		// Exported fields are changed holding the lock.
+		a := ax.Axis(canvas.BottomAxis)
+		f.Lock()
+		a.TickLen = 4 + i%3
+		f.Unlock()
+	}
+
+	wg.Wait()
+	identical(t, f)
+}
+
+// lockFigure returns a Figure with a labeled Line and its Legend.
+func lockFigure(t *testing.T) (*canvas.Figure, *canvas.Axes, *canvas.Legend) {
+	f, err := canvas.NewFigure(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := f.NewAxes()
+	X, Y := wave(100, 0)
+	ax.LinePlot(X, Y, canvas.LegendLabel("wave"))
+	return f, ax, ax.Legend()
+}
+
+// TestLockFields changes the exported fields of a shared Figure holding
+// its lock, and its limits with SetYLim, while it is rendered.
+func TestLockFields(t *testing.T) {
+	f, ax, l := lockFigure(t)
+	a := ax.Axis(canvas.BottomAxis)
+	before := Render(f).(*image.RGBA)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			Render(f)
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		f.Lock()
+		l.Origin = [2]float64{0.5 + 0.01*float64(i), 0.5}
+		a.TickLen = 4 + i%3
+		f.Unlock()
+		ax.SetYLim(-1, 1+math.Mod(float64(i), 3))
+	}
+	wg.Wait()
+
+	// The last changes are drawn as on a Figure built with them.
+	want, wantAx, wantL := lockFigure(t)
+	wantL.Origin = [2]float64{0.5 + 0.01*19, 0.5}
+	wantAx.Axis(canvas.BottomAxis).TickLen = 5
+	wantAx.SetYLim(-1, 2)
+
+	got := Render(f).(*image.RGBA)
+	if bytes.Equal(got.Pix, before.Pix) {
+		t.Error("The changes are not drawn")
+	}
+	if !bytes.Equal(got.Pix, Render(want).(*image.RGBA).Pix) {
+		t.Error("The Figure is not drawn with its last changes")
+	}
+}
@@ -4,6 +4,7 @@ package plt
 import (
 	"image"
 	"image/draw"
+	"sync"
 
 	"github.com/cgxeiji/plt/canvas"
 )
@@ -33,7 +34,10 @@ func Figure(w, h int) (*canvas.Figure, error) {
 }
 
 // Render draws a Figure with all its children into a draw.Image interface.
+// The Figure is locked while it is drawn.
 func Render(f *canvas.Figure) draw.Image {
+	f.Lock()
+	defer f.Unlock()
 	dst := image.NewRGBA(f.Bounds())
 
 	renderAll(f, dst)
@@ -41,9 +45,49 @@ func Render(f *canvas.Figure) draw.Image {
 	return dst
 }
 
+// RenderParallel draws a Figure like Render, drawing the Layers of the
+// Figure concurrently, each into its own region of the image.
+//
+// The Layers cover disjoint pixels and the children of each Layer are
+// drawn in the same order as Render, so the result is byte-identical to
+// Render. A Figure whose children may overlap, or whose pixels cannot be
+// known, is drawn in a single Layer, as Render does.
+// The Figure is locked while it is drawn.
+func RenderParallel(f *canvas.Figure) draw.Image {
+	f.Lock()
+	defer f.Unlock()
+	dst := image.NewRGBA(f.Bounds())
+
+	f.Render(dst)
+	var wg sync.WaitGroup
+	for _, l := range f.Layers() {
+		wg.Add(1)
+		go func(l canvas.Layer) {
+			defer wg.Done()
+			d := canvas.Clip(dst, l.Region)
+			for _, c := range l.Children {
+				renderAll(c, d)
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	return dst
+}
+
+// renderAll draws c and its children into dst.
+// The children of a canvas.Clipper are drawn only inside their
+// clip rectangle.
 func renderAll(c canvas.Container, dst draw.Image) {
 	c.Render(dst)
+	clipper, _ := c.(canvas.Clipper)
 	for _, child := range c.Children() {
-		renderAll(child, dst)
+		d := dst
+		if clipper != nil {
+			if r, ok := clipper.ClipRect(child); ok {
+				d = canvas.Clip(dst, r)
+			}
+		}
+		renderAll(child, d)
 	}
 }
@@ -0,0 +1,231 @@
+package plt
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/cgxeiji/plt/canvas"
+)
+
+// wave returns n samples of a sine wave with the given phase.
+func wave(n int, phase float64) (X, Y []float64) {
+	X = make([]float64, n)
+	Y = make([]float64, n)
+	for i := range X {
+		X[i] = float64(i)
+		Y[i] = math.Sin(float64(i)/8 + phase)
+	}
+	return X, Y
+}
+
+// dashboard returns a Figure with a 4x4 grid of Axes holding every kind
+// of plot, laid out with TightLayout.
+func dashboard(t *testing.T) *canvas.Figure {
+	fig, err := canvas.NewFigure(1200, 900)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs, err := fig.NewGridSpec(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 16; i++ {
+		ax, err := gs.Axes(i/4, i/4+1, i%4, i%4+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		X, Y := wave(60, float64(i))
+		switch i % 8 {
+		case 0:
+			ax.LinePlot(X, Y)
+		case 1:
+			ax.BarPlot([]string{"a", "b", "c", "d"}, []float64{3, 1, 4, 1})
+		case 2:
+			ax.ScatterPlot(X, Y)
+		case 3:
+			ax.Pie([]float64{3, 2, 1}, []string{"x", "y", "z"}, canvas.AutoPct("%.0f%%"))
+		case 4:
+			Y2 := make([]float64, len(Y))
+			ax.FillBetween(X, Y, Y2, canvas.Alpha(0.5))
+		case 5:
+			Yerr := make([]float64, len(Y))
+			for j := range Yerr {
+				Yerr[j] = 0.1
+			}
+			ax.ErrorBar(X[:10], Y[:10], Yerr[:10])
+		case 6:
+			ax.Scatter(X, Y, canvas.Alpha(0.7))
+		case 7:
+			ax.LinePlot(X, Y, canvas.LegendLabel("wave"))
+			ax.HLine(0, canvas.Dashes(canvas.Dashed))
+			ax.Annotate("peak", [2]float64{12, 1}, [2]float64{30, 0.5}, canvas.Arrow(canvas.ArrowSimple))
+			ax.Grid(true)
+		}
+	}
+	fig.TightLayout(4)
+
+	return fig
+}
+
+// overlapped returns a Figure whose children draw over each other:
+// twins, insets, a legend outside its Axes and an annotation pointing
+// into another Axes.
+func overlapped(t *testing.T) *canvas.Figure {
+	fig, err := canvas.NewFigure(900, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	axs, err := fig.SubAxes(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	X, Y := wave(100, 0)
+
+	axs[0].LinePlot(X, Y, canvas.LegendLabel("left"))
+	twin, err := axs[0].TwinX()
+	if err != nil {
+		t.Fatal(err)
+	}
+	twin.LinePlot(X, Y, canvas.LegendLabel("right"), canvas.Color(color.RGBA{200, 0, 0, 255}))
+	axs[0].Legend()
+
+	axs[1].LinePlot(X, Y)
+	in, err := axs[1].Inset([4]float64{0.6, 0.6, 0.35, 0.35})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.LinePlot(X[:20], Y[:20])
+	axs[1].IndicateZoom(in)
+
+	axs[2].ScatterPlot(X, Y)
+	axs[2].Annotate("far", [2]float64{750, 150}, [2]float64{0.5, 0.5},
+		canvas.XYCoords(canvas.FigurePixels), canvas.TextCoords(canvas.AxesCoords),
+		canvas.Arrow(canvas.ArrowCurved))
+
+	axs[3].LinePlot(X, Y, canvas.LegendLabel("a legend drawn outside"))
+	l := axs[3].Legend()
+	axs[4].ScatterPlot(X, Y)
+
+	axs[5].BarPlot([]string{"a", "b"}, []float64{1, 2})
+	axs[5].Annotate("fancy", [2]float64{0, 2}, [2]float64{0.5, 0.8},
+		canvas.TextCoords(canvas.AxesCoords), canvas.Arrow(canvas.ArrowFancy),
+		canvas.TextBox(canvas.BoxStyle{Pad: 3, Radius: 4, Fill: color.White, Edge: color.Black}))
+	fig.TightLayout(8)
+
+	// Moved after the layout, the Legend overlaps the next Axes.
+	l.Origin = [2]float64{1, 1}
+	l.XAlign = canvas.LeftAlign
+
+	return fig
+}
+
+// polar returns a Figure with a PolarAxes, with fixed radial limits
+// if fixed is set.
+func polar(t *testing.T, fixed bool) *canvas.Figure {
+	fig, err := canvas.NewFigure(600, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pa := fig.NewPolarAxes()
+	theta := make([]float64, 36)
+	r := make([]float64, 36)
+	for i := range theta {
+		theta[i] = float64(i) * math.Pi / 18
+		r[i] = 1 + math.Sin(3*theta[i])
+	}
+	pa.LinePlot(theta, r)
+	if fixed {
+		pa.SetRLim(0, 1)
+	}
+	return fig
+}
+
+// identical fails the test if RenderParallel does not draw f exactly
+// as Render does.
+func identical(t *testing.T, f *canvas.Figure) {
+	t.Helper()
+	want := Render(f).(*image.RGBA)
+	got := RenderParallel(f).(*image.RGBA)
+	if !want.Rect.Eq(got.Rect) {
+		t.Fatalf("Bounds %v != %v", got.Rect, want.Rect)
+	}
+	for i := range want.Pix {
+		if want.Pix[i] != got.Pix[i] {
+			x, y := i%want.Stride/4, i/want.Stride
+			j := i - i%4
+			t.Fatalf("Pixel (%v, %v) %v != %v", x, y, got.Pix[j:j+4], want.Pix[j:j+4])
+		}
+	}
+}
+
+func TestRenderParallel(t *testing.T) {
+	tests := []struct {
+		name string
+		fig  func(*testing.T) *canvas.Figure
+		// layers is the number of Layers expected from the Figure.
+		layers int
+	}{
+		{"dashboard", dashboard, 16},
+		{"overlapped", overlapped, 5},
+		{"polar", func(t *testing.T) *canvas.Figure { return polar(t, false) }, 1},
+		{"polar fixed", func(t *testing.T) *canvas.Figure { return polar(t, true) }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fig(t)
+			if n := len(f.Layers()); n != tt.layers {
+				t.Errorf("Layers %v != %v", n, tt.layers)
+			}
+			identical(t, f)
+
+			f.Resize(f.Size[0]*1.5, f.Size[1]*0.8)
+			identical(t, f)
+		})
+	}
+}
+
+func TestRenderParallelDPI(t *testing.T) {
+	f := dashboard(t)
+	if err := f.SetDPI(150); err != nil {
+		t.Fatal(err)
+	}
+	identical(t, f)
+}
+
+func TestRenderNoClip(t *testing.T) {
+	tests := []struct {
+		name   string
+		noClip bool
+	}{
+		{"clipped", false},
+		{"NoClip", true},
+	}
+	for _, tt := range tests {
+		fig, err := canvas.NewFigure(400, 300)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ax := fig.NewAxes()
+		// The line leaves the Axes through its top border.
+		ax.LinePlot([]float64{0.5, 0.5}, []float64{0.5, 2})
+		ax.SetXLim(0, 1)
+		ax.SetYLim(0, 1)
+		for _, c := range ax.Children() {
+			if l, ok := c.(*canvas.Line); ok {
+				l.NoClip = tt.noClip
+			}
+		}
+
+		b := ax.Bounds()
+		x, y := (b.Min.X+b.Max.X)/2, b.Min.Y-5
+		for _, img := range []image.Image{Render(fig), RenderParallel(fig)} {
+			if drawn := img.At(x, y) != img.At(x-20, y); drawn != tt.noClip {
+				t.Errorf("%v: line drawn %v above the Axes, want %v", tt.name, drawn, tt.noClip)
+			}
+		}
+	}
+}
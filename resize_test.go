@@ -0,0 +1,125 @@
+package plt
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/cgxeiji/plt/canvas"
+)
+
+// shared returns a Figure of w by h pixels with three Axes sharing
+// their X limits.
+func shared(t *testing.T, w, h int) *canvas.Figure {
+	fig, err := canvas.NewFigure(w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	axs, err := fig.SubAxes(3, 1, canvas.ShareX())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ax := range axs {
+		X, Y := wave(50, float64(i))
+		ax.LinePlot(X[i*10:], Y[i*10:])
+	}
+	axs[1].SetXLim(5, 45)
+	return fig
+}
+
+// nested returns a Figure of w by h pixels with a nested GridSpec,
+// twin Axes and a Legend, laid out with TightLayout.
+func nested(t *testing.T, w, h int) *canvas.Figure {
+	fig, err := canvas.NewFigure(w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs, err := fig.NewGridSpec(2, 2, canvas.ShareY())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs.WidthRatios = []float64{2, 1}
+	top, err := gs.Axes(0, 1, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	X, Y := wave(60, 0)
+	top.LinePlot(X, Y, canvas.LegendLabel("left"))
+	twin, err := top.TwinX()
+	if err != nil {
+		t.Fatal(err)
+	}
+	twin.ScatterPlot(X, Y, canvas.LegendLabel("right"))
+	top.Legend()
+
+	left, err := gs.Axes(1, 2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left.BarPlot([]string{"a", "b", "c"}, []float64{3, 1, 2})
+	sub, err := gs.SubGridSpec(1, 2, 1, 2, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		ax, err := sub.Axes(i, i+1, 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ax.ScatterPlot(X, Y)
+	}
+	fig.TightLayout(4)
+	return fig
+}
+
+// pieInset returns a Figure of w by h pixels with a Pie and an Axes
+// with a zoomed Inset.
+func pieInset(t *testing.T, w, h int) *canvas.Figure {
+	fig, err := canvas.NewFigure(w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	axs, err := fig.SubAxes(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	axs[0].Pie([]float64{3, 2, 1}, []string{"x", "y", "z"}, canvas.AutoPct("%.0f%%"))
+
+	X, Y := wave(100, 0)
+	axs[1].LinePlot(X, Y)
+	in, err := axs[1].Inset([4]float64{0.55, 0.55, 0.4, 0.4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.LinePlot(X[:20], Y[:20])
+	axs[1].IndicateZoom(in)
+	return fig
+}
+
+// TestResize compares resized Figures with the same Figures built at
+// the new size.
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name string
+		fig  func(t *testing.T, w, h int) *canvas.Figure
+	}{
+		{"shared", shared},
+		{"nested", nested},
+		{"pie inset", pieInset},
+	}
+	for _, tt := range tests {
+		for _, size := range [][2]int{{1000, 500}, {500, 700}} {
+			f := tt.fig(t, 800, 600)
+			f.Resize(float64(size[0]), float64(size[1]))
+			got := Render(f).(*image.RGBA)
+			want := Render(tt.fig(t, size[0], size[1])).(*image.RGBA)
+			if !got.Rect.Eq(want.Rect) {
+				t.Errorf("%v: resized to %v, bounds %v", tt.name, size, got.Rect)
+				continue
+			}
+			if !bytes.Equal(got.Pix, want.Pix) {
+				t.Errorf("%v: resized to %v, differs from a Figure built at that size", tt.name, size)
+			}
+		}
+	}
+}